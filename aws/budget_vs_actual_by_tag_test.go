@@ -0,0 +1,29 @@
+package aws
+
+import (
+	"testing"
+
+	budgetTypes "github.com/aws/aws-sdk-go-v2/service/budgets/types"
+)
+
+func TestBudgetTagValueFilter(t *testing.T) {
+	cases := []struct {
+		name    string
+		filters map[string][]string
+		value   string
+		ok      bool
+	}{
+		{"tag value", map[string][]string{"TagKeyValue": {"user:CostCenter$1234"}}, "1234", true},
+		{"no filters", nil, "", false},
+		{"several values", map[string][]string{"TagKeyValue": {"user:CostCenter$1234", "user:CostCenter$5678"}}, "", false},
+		{"another tag", map[string][]string{"TagKeyValue": {"user:CostCenter$1234", "user:Environment$prod"}}, "", false},
+		{"service filter", map[string][]string{"TagKeyValue": {"user:CostCenter$1234"}, "Service": {"Amazon Elastic Compute Cloud - Compute"}}, "", false},
+	}
+
+	for _, c := range cases {
+		value, ok := budgetTagValueFilter(budgetTypes.Budget{CostFilters: c.filters}, "CostCenter")
+		if value != c.value || ok != c.ok {
+			t.Errorf("%s: budgetTagValueFilter = %q, %v, want %q, %v", c.name, value, ok, c.value, c.ok)
+		}
+	}
+}
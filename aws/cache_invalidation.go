@@ -0,0 +1,259 @@
+package aws
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail"
+	"github.com/aws/aws-sdk-go-v2/service/cloudtrail/types"
+	"github.com/turbot/go-kit/helpers"
+
+	connection_manager "github.com/turbot/steampipe-plugin-sdk/v5/connection"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/context_key"
+)
+
+// Cache invalidation keeps cached query results reasonably fresh without
+// turning the cache off. When "cache_invalidation_interval" is set, a watcher
+// per connection looks up the write (non read-only) CloudTrail management
+// events recorded in the connection's regions, and clears the connection's
+// query cache whenever a new one appears.
+//
+// The plugin SDK can only clear the query cache of a whole connection, so any
+// change in the account invalidates all cached rows of the connection, not
+// just those of the changed resource.
+//
+// The watcher is started when the connection is set up, and restarted when its
+// config changes. The SDK does not call back when a connection is deleted, so
+// the watcher of a deleted connection runs until the plugin restarts.
+
+const (
+	// Minimum cache_invalidation_interval in seconds. LookupEvents is limited
+	// to 2 requests per second per account and region.
+	cacheInvalidationMinInterval = 60
+	// CloudTrail delivers most events within 5 minutes, but may take up to 15,
+	// so each lookup goes back this far and skips events already seen
+	cloudTrailEventDeliveryDelay = 15 * time.Minute
+	// Pages of events looked up per region and interval. Events are returned
+	// newest first, so if there are more, only the newest are checked. In an
+	// account that busy, new events keep showing up among them.
+	cloudTrailLookupMaxPages = 5
+	// LookupEvents is limited to 2 requests per second per account and
+	// region. The watcher's query data has no rate limiters, so pages are
+	// spaced out explicitly.
+	cloudTrailLookupPageInterval = 500 * time.Millisecond
+)
+
+// Set by enableCacheInvalidation. The plugin is needed to clear its query
+// cache, which is not reachable from the query data. The watcher is not
+// referenced statically because it uses the memoized client functions, which
+// read the connection config that starts it, and that would be an
+// initialization cycle.
+var (
+	cacheInvalidationPlugin *plugin.Plugin
+	cacheInvalidationWatch  func(ctx context.Context, d *plugin.QueryData, interval time.Duration)
+)
+
+// enableCacheInvalidation lets connections with "cache_invalidation_interval"
+// set start a watcher that clears the query cache of the plugin
+func enableCacheInvalidation(p *plugin.Plugin) {
+	cacheInvalidationPlugin = p
+	cacheInvalidationWatch = watchCloudTrailWriteEvents
+}
+
+// The running watchers, by connection name. Each is stopped with its cancel
+// func when the config of its connection changes.
+var (
+	cacheInvalidationWatchersMutex sync.Mutex
+	cacheInvalidationWatchers      = map[string]context.CancelFunc{}
+)
+
+// The watcher only caches its own clients and region lists
+const cacheInvalidationConnectionCacheMaxCost = 1000
+
+// startCacheInvalidationWatcher starts the CloudTrail watcher for the
+// connection with the given config, stopping the watcher started for its
+// previous config, if any. It is called by setUpConnection whenever the
+// config of the connection is set.
+func startCacheInvalidationWatcher(connection *plugin.Connection, config awsConfig) {
+	cacheInvalidationWatchersMutex.Lock()
+	defer cacheInvalidationWatchersMutex.Unlock()
+
+	if cancel, ok := cacheInvalidationWatchers[connection.Name]; ok {
+		cancel()
+		delete(cacheInvalidationWatchers, connection.Name)
+	}
+
+	// Invalid configs fail every query, so there is nothing to invalidate
+	if config.CacheInvalidationInterval == nil || cacheInvalidationWatch == nil || checkConnectionConfig(connection.Name, config) != nil {
+		return
+	}
+
+	// The watcher runs outside of any query, so it has its own context and
+	// query data. Its clients are cached with the watcher, so they are built
+	// from the config it was started with and dropped when it stops.
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), context_key.Logger, cacheInvalidationPlugin.Logger))
+	connectionCache, err := connection_manager.NewConnectionCache(connection.Name, cacheInvalidationConnectionCacheMaxCost)
+	if err != nil {
+		plugin.Logger(ctx).Error("startCacheInvalidationWatcher", "connection_name", connection.Name, "cache_error", err)
+		cancel()
+		return
+	}
+	d := &plugin.QueryData{
+		Connection:      connection,
+		ConnectionCache: connectionCache,
+	}
+	cacheInvalidationWatchers[connection.Name] = cancel
+
+	interval := time.Duration(*config.CacheInvalidationInterval) * time.Second
+	plugin.Logger(ctx).Info("startCacheInvalidationWatcher", "connection_name", connection.Name, "interval", interval.String())
+	go cacheInvalidationWatch(ctx, d, interval)
+}
+
+// watchCloudTrailWriteEvents clears the query cache of the connection when
+// new write events are recorded, until ctx is cancelled
+func watchCloudTrailWriteEvents(ctx context.Context, d *plugin.QueryData, interval time.Duration) {
+	tracker := newCloudTrailEventTracker()
+
+	// Events from before the watcher started are already reflected in the
+	// cache, so the first lookup only records them
+	if _, err := lookupCloudTrailWriteEvents(ctx, d, tracker); err != nil && ctx.Err() == nil {
+		plugin.Logger(ctx).Warn("watchCloudTrailWriteEvents", "connection_name", d.Connection.Name, "lookup_error", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			plugin.Logger(ctx).Info("watchCloudTrailWriteEvents", "connection_name", d.Connection.Name, "status", "stopped")
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := lookupCloudTrailWriteEvents(ctx, d, tracker)
+		if err != nil {
+			// Keep the cache, the changes are picked up by the next lookup
+			if ctx.Err() == nil {
+				plugin.Logger(ctx).Warn("watchCloudTrailWriteEvents", "connection_name", d.Connection.Name, "lookup_error", err)
+			}
+			continue
+		}
+		if !changed {
+			continue
+		}
+
+		plugin.Logger(ctx).Info("watchCloudTrailWriteEvents", "connection_name", d.Connection.Name, "status", "clearing_query_cache")
+		if err := cacheInvalidationPlugin.ClearQueryCache(ctx, d.Connection.Name); err != nil {
+			plugin.Logger(ctx).Error("watchCloudTrailWriteEvents", "connection_name", d.Connection.Name, "clear_cache_error", err)
+		}
+	}
+}
+
+// lookupCloudTrailWriteEvents returns true if a write event not seen before
+// was recorded in any of the connection's regions, or in the partition's
+// global region where changes to global services such as IAM are recorded
+func lookupCloudTrailWriteEvents(ctx context.Context, d *plugin.QueryData, tracker *cloudTrailEventTracker) (bool, error) {
+	regions, err := listQueryRegionsForConnection(ctx, d)
+	if err != nil {
+		return false, err
+	}
+	globalRegion, err := getLastResortRegion(ctx, d, nil)
+	if err != nil {
+		return false, err
+	}
+	if !helpers.StringSliceContains(regions, globalRegion) {
+		regions = append(regions, globalRegion)
+	}
+
+	now := time.Now()
+	changed := false
+	for _, region := range regions {
+		regionChanged, err := lookupCloudTrailWriteEventsForRegion(ctx, d, region, now.Add(-cloudTrailEventDeliveryDelay), tracker)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || regionChanged
+	}
+
+	tracker.prune(now.Add(-cloudTrailEventDeliveryDelay))
+	return changed, nil
+}
+
+func lookupCloudTrailWriteEventsForRegion(ctx context.Context, d *plugin.QueryData, region string, startTime time.Time, tracker *cloudTrailEventTracker) (bool, error) {
+	svc, err := CloudTrailRegionsClient(ctx, d, region)
+	if err != nil {
+		return false, err
+	}
+
+	input := &cloudtrail.LookupEventsInput{
+		LookupAttributes: []types.LookupAttribute{
+			{
+				AttributeKey:   types.LookupAttributeKeyReadOnly,
+				AttributeValue: aws.String("false"),
+			},
+		},
+		StartTime:  aws.Time(startTime),
+		MaxResults: aws.Int32(50),
+	}
+
+	pageTicker := time.NewTicker(cloudTrailLookupPageInterval)
+	defer pageTicker.Stop()
+
+	// Events on the pages looked up are recorded even when the rest are
+	// skipped, so they are not counted as new again by the next lookup
+	changed := false
+	paginator := cloudtrail.NewLookupEventsPaginator(svc, input)
+	for page := 0; paginator.HasMorePages() && page < cloudTrailLookupMaxPages; page++ {
+		if page > 0 {
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-pageTicker.C:
+			}
+		}
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return false, err
+		}
+		for _, event := range output.Events {
+			if tracker.observe(aws.ToString(event.EventId), aws.ToTime(event.EventTime)) {
+				changed = true
+			}
+		}
+	}
+
+	return changed, nil
+}
+
+// cloudTrailEventTracker remembers the events seen during the delivery delay
+// window, so events returned by overlapping lookups are only counted once
+type cloudTrailEventTracker struct {
+	seen map[string]time.Time
+}
+
+func newCloudTrailEventTracker() *cloudTrailEventTracker {
+	return &cloudTrailEventTracker{seen: map[string]time.Time{}}
+}
+
+// observe records the event and returns true if it had not been seen before
+func (t *cloudTrailEventTracker) observe(eventId string, eventTime time.Time) bool {
+	if _, ok := t.seen[eventId]; ok {
+		return false
+	}
+	t.seen[eventId] = eventTime
+	return true
+}
+
+// prune forgets events older than the given time, which lookups no longer
+// return
+func (t *cloudTrailEventTracker) prune(before time.Time) {
+	for eventId, eventTime := range t.seen {
+		if eventTime.Before(before) {
+			delete(t.seen, eventId)
+		}
+	}
+}
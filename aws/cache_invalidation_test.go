@@ -0,0 +1,77 @@
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestCloudTrailEventTracker(t *testing.T) {
+	now := time.Now()
+	tracker := newCloudTrailEventTracker()
+
+	if !tracker.observe("a", now.Add(-20*time.Minute)) {
+		t.Error("first observe of event a returned false")
+	}
+	if !tracker.observe("b", now.Add(-time.Minute)) {
+		t.Error("first observe of event b returned false")
+	}
+	if tracker.observe("b", now.Add(-time.Minute)) {
+		t.Error("second observe of event b returned true")
+	}
+
+	tracker.prune(now.Add(-cloudTrailEventDeliveryDelay))
+	if _, ok := tracker.seen["a"]; ok {
+		t.Error("event a outside the delivery window was not pruned")
+	}
+	if tracker.observe("b", now.Add(-time.Minute)) {
+		t.Error("event b inside the delivery window was pruned")
+	}
+}
+
+func TestCacheInvalidationWatcherLifecycle(t *testing.T) {
+	defer func(p *plugin.Plugin, watch func(context.Context, *plugin.QueryData, time.Duration)) {
+		cacheInvalidationPlugin, cacheInvalidationWatch = p, watch
+	}(cacheInvalidationPlugin, cacheInvalidationWatch)
+
+	type watcher struct {
+		ctx      context.Context
+		interval time.Duration
+	}
+	started := make(chan watcher, 2)
+	cacheInvalidationPlugin = &plugin.Plugin{Logger: hclog.NewNullLogger()}
+	cacheInvalidationWatch = func(ctx context.Context, _ *plugin.QueryData, interval time.Duration) {
+		started <- watcher{ctx, interval}
+	}
+
+	interval := func(v int) *int { return &v }
+	connection := &plugin.Connection{Name: "aws_cache_invalidation_test"}
+
+	startCacheInvalidationWatcher(connection, awsConfig{CacheInvalidationInterval: interval(300)})
+	first := <-started
+	if first.interval != 300*time.Second {
+		t.Errorf("first watcher interval = %s, want 5m0s", first.interval)
+	}
+
+	// A changed config replaces the watcher
+	startCacheInvalidationWatcher(connection, awsConfig{CacheInvalidationInterval: interval(600)})
+	second := <-started
+	if first.ctx.Err() == nil {
+		t.Error("first watcher was not stopped when the config changed")
+	}
+	if second.interval != 600*time.Second {
+		t.Errorf("second watcher interval = %s, want 10m0s", second.interval)
+	}
+
+	// Removing the interval stops the watcher
+	startCacheInvalidationWatcher(connection, awsConfig{})
+	if second.ctx.Err() == nil {
+		t.Error("second watcher was not stopped when cache invalidation was disabled")
+	}
+	if _, ok := cacheInvalidationWatchers[connection.Name]; ok {
+		t.Error("stopped watcher is still registered")
+	}
+}
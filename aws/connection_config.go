@@ -1,24 +1,40 @@
 package aws
 
 import (
+	"context"
 	"fmt"
+	"net/url"
+	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
 )
 
 type awsConfig struct {
-	Regions               []string `hcl:"regions,optional"`
-	DefaultRegion         *string  `hcl:"default_region"`
-	Profile               *string  `hcl:"profile"`
-	AccessKey             *string  `hcl:"access_key"`
-	SecretKey             *string  `hcl:"secret_key"`
-	SessionToken          *string  `hcl:"session_token"`
-	MaxErrorRetryAttempts *int     `hcl:"max_error_retry_attempts"`
-	MinErrorRetryDelay    *int     `hcl:"min_error_retry_delay"`
-	IgnoreErrorCodes      []string `hcl:"ignore_error_codes,optional"`
-	EndpointUrl           *string  `hcl:"endpoint_url"`
-	S3ForcePathStyle      *bool    `hcl:"s3_force_path_style"`
+	Regions                   []string          `hcl:"regions,optional"`
+	DefaultRegion             *string           `hcl:"default_region"`
+	Profile                   *string           `hcl:"profile"`
+	AccessKey                 *string           `hcl:"access_key"`
+	SecretKey                 *string           `hcl:"secret_key"`
+	SessionToken              *string           `hcl:"session_token"`
+	MaxErrorRetryAttempts     *int              `hcl:"max_error_retry_attempts"`
+	MinErrorRetryDelay        *int              `hcl:"min_error_retry_delay"`
+	IgnoreErrorCodes          []string          `hcl:"ignore_error_codes,optional"`
+	EndpointUrl               *string           `hcl:"endpoint_url"`
+	S3ForcePathStyle          *bool             `hcl:"s3_force_path_style"`
+	IamActionDataSource       *string           `hcl:"iam_action_data_source"`
+	RedactColumns             []string          `hcl:"redact_columns,optional"`
+	OtelEndpoint              *string           `hcl:"otel_endpoint"`
+	OtelInsecure              *bool             `hcl:"otel_insecure"`
+	AbacTagKeys               []string          `hcl:"abac_tag_keys,optional"`
+	WellKnownAccounts         map[string]string `hcl:"wellknown_accounts,optional"`
+	TrustedAccounts           []string          `hcl:"trusted_accounts,optional"`
+	ScanProfile               *string           `hcl:"scan_profile"`
+	CacheInvalidationInterval *int              `hcl:"cache_invalidation_interval"`
 }
 
 func ConfigInstance() interface{} {
@@ -44,9 +60,140 @@ func GetConfig(connection *plugin.Connection) awsConfig {
 		}
 	}
 
+	// The SDK only calls back when the config of a connection changes, not
+	// when a connection is first added, so new connections are set up the
+	// first time their config is read
+	if _, ok := setUpConnections.LoadOrStore(connection.Name, true); !ok {
+		setUpConnection(connection, config)
+	}
+
 	return config
 }
 
+// Names of the connections whose config has been set up by setUpConnection
+var setUpConnections sync.Map
+
+// connectionConfigChanged is called by the SDK when the config of a
+// connection changes. It sets up the connection with the new config, then
+// clears its caches, as the SDK does by default.
+func connectionConfigChanged(ctx context.Context, p *plugin.Plugin, old, new *plugin.Connection) error {
+	setUpConnections.Store(new.Name, true)
+	setUpConnection(new, GetConfig(new))
+
+	if err := p.ClearConnectionCache(ctx, new.Name); err != nil {
+		return err
+	}
+	return p.ClearQueryCache(ctx, new.Name)
+}
+
+// setUpConnection applies the parts of a connection config that are used
+// outside of its queries. Transforms cannot read the connection config, so
+// the "redact_columns" patterns are registered for the redaction transform,
+// and the cache invalidation watcher is started for the config.
+func setUpConnection(connection *plugin.Connection, config awsConfig) {
+	redactionPatterns.register(connection.Name, config.RedactColumns)
+	startCacheInvalidationWatcher(connection, config)
+}
+
+var (
+	// e.g. us-east-1 or cn-northwest-1. Names are not checked against a list of
+	// regions, so regions launched after this release can be queried.
+	regionNameRegex = regexp.MustCompile(`^[a-z0-9-]+$`)
+	// e.g. us-*, *-east-?, us-[ew]* or *
+	regionPatternRegex = regexp.MustCompile(`^[a-z0-9*?\[\]^-]+$`)
+)
+
+// validateConfig checks the connection config for values that can never
+// work, returning a message for each problem found. Problems are reported
+// before any AWS call is made, so the message can point at the option to fix
+// instead of surfacing as an SDK or API error.
+func validateConfig(config awsConfig) []string {
+	var problems []string
+
+	for _, region := range config.Regions {
+		if strings.ContainsAny(region, "*?[") {
+			if !regionPatternRegex.MatchString(region) || !wildcard.ValidGlob(region) {
+				problems = append(problems, fmt.Sprintf("\"regions\" has invalid pattern %q, wildcard patterns may only contain letters, digits, '-', '*', '?' and character classes such as [ew]", region))
+			}
+		} else if !regionNameRegex.MatchString(region) {
+			problems = append(problems, fmt.Sprintf("\"regions\" has invalid region %q, region names may only contain lower case letters, digits and '-'", region))
+		}
+	}
+	if config.DefaultRegion != nil && !regionNameRegex.MatchString(NormalizeRegion(*config.DefaultRegion)) {
+		problems = append(problems, fmt.Sprintf("\"default_region\" has invalid region %q, expected a region name such as us-east-1; wildcards are not supported", *config.DefaultRegion))
+	}
+
+	if config.AccessKey != nil && config.SecretKey == nil {
+		problems = append(problems, "partial credentials found in connection config, missing: secret_key")
+	} else if config.SecretKey != nil && config.AccessKey == nil {
+		problems = append(problems, "partial credentials found in connection config, missing: access_key")
+	}
+	if config.SessionToken != nil && config.AccessKey == nil && config.SecretKey == nil {
+		problems = append(problems, "\"session_token\" requires \"access_key\" and \"secret_key\"")
+	}
+
+	if config.EndpointUrl != nil && *config.EndpointUrl != "" {
+		u, err := url.Parse(*config.EndpointUrl)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			problems = append(problems, fmt.Sprintf("\"endpoint_url\" has invalid value %q, expected an http or https URL such as https://localhost:4566", *config.EndpointUrl))
+		}
+	}
+
+	for accountId := range config.WellKnownAccounts {
+		if !policyeval.IsAccountID(accountId) {
+			problems = append(problems, fmt.Sprintf("\"wellknown_accounts\" has invalid account ID %q, expected a 12 digit AWS account ID", accountId))
+		}
+	}
+
+	for _, accountId := range config.TrustedAccounts {
+		if !policyeval.IsAccountID(accountId) {
+			problems = append(problems, fmt.Sprintf("\"trusted_accounts\" has invalid account ID %q, expected a 12 digit AWS account ID", accountId))
+		}
+	}
+
+	if config.ScanProfile != nil {
+		if _, ok := scanProfiles[*config.ScanProfile]; !ok {
+			problems = append(problems, fmt.Sprintf("\"scan_profile\" has invalid value %q, expected %q or %q", *config.ScanProfile, scanProfileDefault, scanProfileGentle))
+		}
+	}
+
+	if config.CacheInvalidationInterval != nil && *config.CacheInvalidationInterval < cacheInvalidationMinInterval {
+		problems = append(problems, fmt.Sprintf("\"cache_invalidation_interval\" must be greater than or equal to %d seconds", cacheInvalidationMinInterval))
+	}
+
+	if config.MaxErrorRetryAttempts != nil && *config.MaxErrorRetryAttempts < 1 {
+		problems = append(problems, "\"max_error_retry_attempts\" must be greater than or equal to 1")
+	}
+	if config.MinErrorRetryDelay != nil && *config.MinErrorRetryDelay < 1 {
+		problems = append(problems, "\"min_error_retry_delay\" must be greater than or equal to 1")
+	}
+
+	return problems
+}
+
+// checkConnectionConfig returns an error listing the problems found by
+// validateConfig, or nil if the config is valid
+func checkConnectionConfig(connectionName string, config awsConfig) error {
+	if problems := validateConfig(config); len(problems) > 0 {
+		return fmt.Errorf("connection %s has invalid config: %s", connectionName, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// configWarnings returns a message for each connection config value that
+// works, but probably not the way it was meant to
+func configWarnings(config awsConfig) []string {
+	var warnings []string
+
+	// The key pair takes precedence over any credentials in the profile, the
+	// profile still sets the region and other options
+	if config.Profile != nil && config.AccessKey != nil && config.SecretKey != nil {
+		warnings = append(warnings, "\"profile\" is set with \"access_key\" and \"secret_key\", the key pair is used instead of the credentials of the profile")
+	}
+
+	return warnings
+}
+
 func NormalizeRegion(region string) string {
 	// ensure regions are lower case, to work consistently in matching
 	// and comparisons
@@ -0,0 +1,54 @@
+package aws
+
+import (
+	"testing"
+)
+
+func TestValidateConfig(t *testing.T) {
+	s := func(v string) *string { return &v }
+	i := func(v int) *int { return &v }
+
+	cases := []struct {
+		name     string
+		config   awsConfig
+		problems int
+	}{
+		{"empty", awsConfig{}, 0},
+		{"regions", awsConfig{Regions: []string{"us-east-1", "us-gov-west-1", "cn-northwest-1", "us-isob-east-1", "eu-*", "us-[ew]*", "*"}}, 0},
+		{"future regions", awsConfig{Regions: []string{"ca-west-1", "ap-southeast-7"}}, 0},
+		{"invalid regions", awsConfig{Regions: []string{"US_EAST_1", "us east 1", "eu-[a-z*"}}, 3},
+		{"default region", awsConfig{DefaultRegion: s("US-EAST-1")}, 0},
+		{"default region wildcard", awsConfig{DefaultRegion: s("us-*")}, 1},
+		{"profile", awsConfig{Profile: s("dev")}, 0},
+		{"key pair", awsConfig{AccessKey: s("AKIA"), SecretKey: s("secret"), SessionToken: s("token")}, 0},
+		{"profile and key pair", awsConfig{Profile: s("dev"), AccessKey: s("AKIA"), SecretKey: s("secret")}, 0},
+		{"partial key pair", awsConfig{AccessKey: s("AKIA")}, 1},
+		{"session token only", awsConfig{SessionToken: s("token")}, 1},
+		{"endpoint url", awsConfig{EndpointUrl: s("http://localhost:4566")}, 0},
+		{"endpoint url without scheme", awsConfig{EndpointUrl: s("localhost:4566")}, 1},
+		{"retries", awsConfig{MaxErrorRetryAttempts: i(0), MinErrorRetryDelay: i(0)}, 2},
+		{"scan profile", awsConfig{ScanProfile: s("gentle")}, 0},
+		{"invalid scan profile", awsConfig{ScanProfile: s("slow")}, 1},
+		{"cache invalidation interval", awsConfig{CacheInvalidationInterval: i(300)}, 0},
+		{"cache invalidation interval too short", awsConfig{CacheInvalidationInterval: i(10)}, 1},
+		{"wellknown accounts", awsConfig{WellKnownAccounts: map[string]string{"123456789012": "Log archive", "log-archive": "Log archive"}}, 1},
+		{"trusted accounts", awsConfig{TrustedAccounts: []string{"123456789012", "1234"}}, 1},
+	}
+
+	for _, c := range cases {
+		if problems := validateConfig(c.config); len(problems) != c.problems {
+			t.Errorf("%s: got %d problems %q, want %d", c.name, len(problems), problems, c.problems)
+		}
+	}
+}
+
+func TestConfigWarnings(t *testing.T) {
+	s := func(v string) *string { return &v }
+
+	if warnings := configWarnings(awsConfig{Profile: s("dev")}); len(warnings) != 0 {
+		t.Errorf("profile: got warnings %q, want none", warnings)
+	}
+	if warnings := configWarnings(awsConfig{Profile: s("dev"), AccessKey: s("AKIA"), SecretKey: s("secret")}); len(warnings) != 1 {
+		t.Errorf("profile and key pair: got warnings %q, want 1", warnings)
+	}
+}
@@ -0,0 +1,14 @@
+package aws
+
+import "testing"
+
+func TestDeprecatedLambdaRuntimes(t *testing.T) {
+	for runtime, deprecation := range deprecatedLambdaRuntimes {
+		if _, ok := deprecatedLambdaRuntimes[deprecation.MigrationTarget]; ok {
+			t.Errorf("%s: migration target %s is also deprecated", runtime, deprecation.MigrationTarget)
+		}
+		if deprecation.Deadline.IsZero() {
+			t.Errorf("%s: no deadline", runtime)
+		}
+	}
+}
@@ -3,15 +3,22 @@ package aws
 import (
 	"context"
 	"errors"
-	"path"
 
 	"github.com/aws/smithy-go"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
 )
 
 // shouldIgnoreErrors:: function which returns an ErrorPredicate for AWS API calls
 func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+		// Skip regions the credentials cannot be used in, e.g. regions that are
+		// not opted in or have the STS regional endpoint deactivated
+		if shouldSkipRegionForError(ctx, d, err) {
+			return true
+		}
+
 		awsConfig := GetConfig(d.Connection)
 
 		// If the get or list hydrate functions have an overriding IgnoreConfig
@@ -20,11 +27,9 @@ func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContex
 		allErrors := append(notFoundErrors, awsConfig.IgnoreErrorCodes...)
 		var ae smithy.APIError
 		if errors.As(err, &ae) {
-			// Added to support regex in not found errors
-			for _, pattern := range allErrors {
-				if ok, _ := path.Match(pattern, ae.ErrorCode()); ok {
-					return true
-				}
+			// Added to support wildcards in not found errors
+			if wildcard.MatchAnyGlob(allErrors, ae.ErrorCode()) {
+				return true
 			}
 		}
 		return false
@@ -34,6 +39,10 @@ func shouldIgnoreErrors(notFoundErrors []string) plugin.ErrorPredicateWithContex
 // shouldIgnoreErrorPluginDefault:: Plugin level default function to ignore a set errors for hydrate functions based on "ignore_error_codes" config argument
 func shouldIgnoreErrorPluginDefault() plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+		if shouldSkipRegionForError(ctx, d, err) {
+			return true
+		}
+
 		if !hasIgnoredErrorCodes(d.Connection) {
 			return false
 		}
@@ -41,11 +50,9 @@ func shouldIgnoreErrorPluginDefault() plugin.ErrorPredicateWithContext {
 		awsConfig := GetConfig(d.Connection)
 		var ae smithy.APIError
 		if errors.As(err, &ae) {
-			// Added to support regex in not found errors
-			for _, pattern := range awsConfig.IgnoreErrorCodes {
-				if ok, _ := path.Match(pattern, ae.ErrorCode()); ok {
-					return true
-				}
+			// Added to support wildcards in not found errors
+			if wildcard.MatchAnyGlob(awsConfig.IgnoreErrorCodes, ae.ErrorCode()) {
+				return true
 			}
 		}
 		return false
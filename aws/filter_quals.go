@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+// Quals pushed down to API filters only need to narrow the results returned by
+// AWS. Postgres re-checks every qual against the returned rows, so a qual that
+// cannot be translated exactly is simply not pushed down rather than
+// approximated.
+
+// tagQualFilter is a tag filter built from a qual on the "tags" column. A nil
+// Value matches any resource with the tag key.
+type tagQualFilter struct {
+	Key   string
+	Value *string
+}
+
+// getTagQualFilters converts quals on a "tags" column into tag filters:
+//
+//	tags @> '{"env": "prod"}' -- resources with the tag env = prod
+//	tags ? 'env'              -- resources with the tag key env
+//
+// Containment values that are not JSON objects of string values are skipped.
+func getTagQualFilters(keyQuals plugin.KeyColumnQualMap, columnName string) []tagQualFilter {
+	var filters []tagQualFilter
+	if keyQuals[columnName] == nil {
+		return filters
+	}
+
+	for _, q := range keyQuals[columnName].Quals {
+		switch q.Operator {
+		case quals.QualOperatorJsonbContainsLeftRight:
+			value := q.Value.GetJsonbValue()
+			if value == "" {
+				value = q.Value.GetStringValue()
+			}
+			var tags map[string]interface{}
+			if err := json.Unmarshal([]byte(value), &tags); err != nil {
+				continue
+			}
+			keys := make([]string, 0, len(tags))
+			for k := range tags {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				if s, ok := tags[k].(string); ok {
+					filters = append(filters, tagQualFilter{Key: k, Value: &s})
+				}
+			}
+		case quals.QualOperatorJsonbExistsOne:
+			if key := q.Value.GetStringValue(); key != "" {
+				filters = append(filters, tagQualFilter{Key: key})
+			}
+		}
+	}
+	return filters
+}
+
+// likePatternToSubstring converts a LIKE pattern of the form 'abc%' or '%abc%'
+// into the literal substring and whether it is a prefix match. Patterns with
+// other wildcards or escapes cannot be expressed as an API filter and return
+// ok == false.
+func likePatternToSubstring(pattern string) (value string, prefix bool, ok bool) {
+	if !strings.HasSuffix(pattern, "%") {
+		return "", false, false
+	}
+	value = strings.TrimSuffix(pattern, "%")
+	prefix = true
+	if strings.HasPrefix(value, "%") {
+		value = strings.TrimPrefix(value, "%")
+		prefix = false
+	}
+	if value == "" || strings.ContainsAny(value, `%_\`) {
+		return "", false, false
+	}
+	return value, prefix, true
+}
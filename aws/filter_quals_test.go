@@ -0,0 +1,60 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+func TestGetTagQualFilters(t *testing.T) {
+	keyQuals := plugin.KeyColumnQualMap{
+		"tags": &plugin.KeyColumnQuals{
+			Name: "tags",
+			Quals: quals.QualSlice{
+				{Column: "tags", Operator: "@>", Value: &proto.QualValue{Value: &proto.QualValue_JsonbValue{JsonbValue: `{"env": "prod", "count": 1, "app": "web"}`}}},
+				{Column: "tags", Operator: "?", Value: &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: "owner"}}},
+				{Column: "tags", Operator: "@>", Value: &proto.QualValue{Value: &proto.QualValue_JsonbValue{JsonbValue: `["not", "an", "object"]`}}},
+			},
+		},
+	}
+
+	app, env := "web", "prod"
+	expected := []tagQualFilter{
+		{Key: "app", Value: &app},
+		{Key: "env", Value: &env},
+		{Key: "owner"},
+	}
+	if actual := getTagQualFilters(keyQuals, "tags"); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("getTagQualFilters:\nexpected: %+v\nactual:   %+v", expected, actual)
+	}
+	if actual := getTagQualFilters(keyQuals, "tags_src"); len(actual) != 0 {
+		t.Errorf("getTagQualFilters: expected no filters for a column without quals, got %+v", actual)
+	}
+}
+
+func TestLikePatternToSubstring(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		value   string
+		prefix  bool
+		ok      bool
+	}{
+		{"abc%", "abc", true, true},
+		{"%abc%", "abc", false, true},
+		{"%abc", "", false, false},
+		{"abc", "", false, false},
+		{"a_c%", "", false, false},
+		{"a%c%", "", false, false},
+		{`a\%%`, "", false, false},
+		{"%", "", false, false},
+	}
+	for _, tc := range testCases {
+		value, prefix, ok := likePatternToSubstring(tc.pattern)
+		if value != tc.value || prefix != tc.prefix || ok != tc.ok {
+			t.Errorf("likePatternToSubstring(%q): expected (%q, %t, %t), got (%q, %t, %t)", tc.pattern, tc.value, tc.prefix, tc.ok, value, prefix, ok)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+// Building the action list walks the whole IAM permissions data, so it is
+// done once per connection
+var getIamKnownActionsCached = plugin.HydrateFunc(getIamKnownActionsUncached).Memoize()
+
+// getIamKnownActionsUncached returns the policyeval.KnownActions of the IAM
+// permissions data, used to tell which actions in a policy exist
+func getIamKnownActionsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	permissions, err := getIamPermissionsData(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions.KnownActions(), nil
+}
+
+// Expansions of action patterns are reused by every policy of a connection
+var getIamActionExpanderCached = plugin.HydrateFunc(getIamActionExpanderUncached).Memoize()
+
+// getIamActionExpanderUncached returns a policyeval.ActionExpander for the
+// known actions, used to count the actions a policy refers to
+func getIamActionExpanderUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	knownActions, err := getIamKnownActionsCached(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	return policyeval.NewActionExpander(knownActions.(policyeval.KnownActions)), nil
+}
+
+// Like the action list, the action metadata is built once per connection
+var getIamActionMetadataCached = plugin.HydrateFunc(getIamActionMetadataUncached).Memoize()
+
+// getIamActionMetadataUncached returns the policyeval.ActionMetadata of the
+// IAM permissions data, used to describe the actions a statement grants
+func getIamActionMetadataUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	permissions, err := getIamPermissionsData(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	return permissions.ActionMetadata(), nil
+}
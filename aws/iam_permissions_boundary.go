@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+
+	"github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+// iamWildcardAction is the action pattern that grants full access to IAM. A
+// statement whose actions cover it (e.g. "*", "iam:*" or "i*") lets the
+// principal manage any user, role or policy, and so escalate to admin.
+const iamWildcardAction = "iam:*"
+
+//// HYDRATE HELPERS
+
+// getIamPermissionsBoundaryPolicyVersion returns the default version of the
+// managed policy used as a permissions boundary
+func getIamPermissionsBoundaryPolicyVersion(ctx context.Context, d *plugin.QueryData, arn string) (*iam.GetPolicyVersionOutput, error) {
+	// Get client
+	svc, err := IAMClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := svc.GetPolicy(ctx, &iam.GetPolicyInput{PolicyArn: aws.String(arn)})
+	if err != nil {
+		return nil, err
+	}
+
+	return svc.GetPolicyVersion(ctx, &iam.GetPolicyVersionInput{
+		PolicyArn: policy.Policy.Arn,
+		VersionId: policy.Policy.DefaultVersionId,
+	})
+}
+
+//// TRANSFORM FUNCTIONS
+
+// permissionsBoundaryRestrictsAdminActions takes an (unescaped) permissions
+// boundary document and returns true if the boundary stops the principal
+// using iam:* or *, i.e. it does not allow them or it explicitly denies them.
+// Returns nil if there is no boundary.
+func permissionsBoundaryRestrictsAdminActions(ctx context.Context, d *transform.TransformData) (interface{}, error) {
+	data := types.SafeString(d.Value)
+	if data == "" {
+		return nil, nil
+	}
+
+	policy, err := canonicalPolicy(data)
+	if err != nil {
+		plugin.Logger(ctx).Error("permissionsBoundaryRestrictsAdminActions", "transform_error", err)
+		return nil, err
+	}
+
+	boundary := policy.(Policy)
+	return !policyeval.GrantsAction(boundary, iamWildcardAction) || policyeval.DeniesAction(boundary, iamWildcardAction), nil
+}
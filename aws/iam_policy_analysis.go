@@ -0,0 +1,14 @@
+package aws
+
+import (
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+// iamPolicyAnalysis is the result of analyzing a policy attached to a
+// resource or principal
+type iamPolicyAnalysis struct {
+	IneffectiveStatementIds []string
+	Metrics                 policyeval.Metrics
+	// Evaluation is only set for resource policies
+	Evaluation policyeval.EvaluatedPolicy
+}
@@ -0,0 +1,52 @@
+package aws
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+//// HYDRATE FUNCTIONS
+
+// getAwsIamRoleTrustPolicySessionControls analyzes the trust policy of the
+// role. The trust policy is returned by both ListRoles and GetRole, so no API
+// call is made.
+func getAwsIamRoleTrustPolicySessionControls(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	role := h.Item.(types.Role)
+	if role.AssumeRolePolicyDocument == nil {
+		return nil, nil
+	}
+
+	document, err := url.QueryUnescape(*role.AssumeRolePolicyDocument)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_role.getAwsIamRoleTrustPolicySessionControls", "unescape_error", err)
+		return nil, err
+	}
+
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_role.getAwsIamRoleTrustPolicySessionControls", "policy_error", err)
+		return nil, err
+	}
+
+	knownActions, err := getIamKnownActionsCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_role.getAwsIamRoleTrustPolicySessionControls", "iam_permissions_error", err)
+		return nil, err
+	}
+
+	// The role's own account and the configured trusted accounts are "self"
+	opts := policyeval.TrustPolicyOptions{
+		AccountIds:          append([]string{policyeval.ARNAccountID(aws.ToString(role.Arn))}, GetConfig(d.Connection).TrustedAccounts...),
+		WellKnownAccountIds: getWellKnownAccountIds(d),
+	}
+	controls := policyeval.AnalyzeTrustPolicy(policy.(Policy), opts)
+	controls.IneffectiveStatementIds = policyeval.IneffectiveStatementIds(policy.(Policy), "", knownActions.(policyeval.KnownActions))
+	return controls, nil
+}
@@ -63,7 +63,6 @@ package aws
 import (
 	"context"
 	"fmt"
-	"path"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -78,6 +77,8 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/logging"
 	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
 )
 
 const matrixKeyRegion = "region"
@@ -237,7 +238,7 @@ func listQueryRegionsForConnection(ctx context.Context, d *plugin.QueryData) ([]
 	var targetRegions []string
 	for _, pattern := range awsSpcConfig.Regions {
 		for _, validRegion := range maxTargetRegions {
-			if ok, _ := path.Match(pattern, validRegion); ok {
+			if wildcard.MatchGlob(pattern, validRegion) {
 				targetRegions = append(targetRegions, validRegion)
 			}
 		}
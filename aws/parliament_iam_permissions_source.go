@@ -0,0 +1,194 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/parliament"
+)
+
+// The built-in IAM permissions data is generated from Parliament at build
+// time (see scripts/generate_parliament_iam_permissions), so actions released
+// by AWS after the plugin was built are unknown. The "iam_action_data_source"
+// connection config argument allows a newer snapshot to be loaded at runtime
+// from a local file or a http(s) URL. The snapshot uses the same format as
+// Parliament's iam_definition.json.
+
+// The built-in data is in internal/parliament, so the policyeval command can
+// use it too
+type (
+	ParliamentCondition    = parliament.Condition
+	ParliamentResourceType = parliament.ResourceType
+	ParliamentPrivilege    = parliament.Privilege
+	ParliamentResource     = parliament.Resource
+	ParliamentService      = parliament.Service
+	ParliamentPermissions  = parliament.Permissions
+)
+
+func getParliamentIamPermissions() ParliamentPermissions {
+	return parliament.IamPermissions()
+}
+
+// parliamentSnapshotService matches a service entry in iam_definition.json
+type parliamentSnapshotService struct {
+	Conditions []struct {
+		Condition   string `json:"condition"`
+		Description string `json:"description"`
+		Type        string `json:"type"`
+	} `json:"conditions"`
+	Prefix     string `json:"prefix"`
+	Privileges []struct {
+		AccessLevel   string `json:"access_level"`
+		Description   string `json:"description"`
+		Privilege     string `json:"privilege"`
+		ResourceTypes []struct {
+			ConditionKeys    []string `json:"condition_keys"`
+			DependentActions []string `json:"dependent_actions"`
+			ResourceType     string   `json:"resource_type"`
+		} `json:"resource_types"`
+	} `json:"privileges"`
+	Resources []struct {
+		Arn           string   `json:"arn"`
+		ConditionKeys []string `json:"condition_keys"`
+		Resource      string   `json:"resource"`
+	} `json:"resources"`
+	ServiceName string `json:"service_name"`
+}
+
+// getIamPermissionsData returns the IAM permissions data for the connection,
+// which is either the built-in Parliament data or the snapshot configured in
+// "iam_action_data_source".
+func getIamPermissionsData(ctx context.Context, d *plugin.QueryData) (ParliamentPermissions, error) {
+	awsSpcConfig := GetConfig(d.Connection)
+	if awsSpcConfig.IamActionDataSource == nil || *awsSpcConfig.IamActionDataSource == "" {
+		return permissionsData, nil
+	}
+
+	data, err := getIamPermissionsSnapshotCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
+	}
+	return data.(ParliamentPermissions), nil
+}
+
+// Snapshots are expected to be refreshed regularly (e.g. weekly), so cache
+// the loaded data per connection for a day rather than indefinitely.
+var getIamPermissionsSnapshotCached = plugin.HydrateFunc(getIamPermissionsSnapshotUncached).Memoize(memoize.WithTtl(time.Hour * 24))
+
+func getIamPermissionsSnapshotUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	source := *GetConfig(d.Connection).IamActionDataSource
+
+	content, err := readIamPermissionsSnapshot(ctx, source)
+	if err != nil {
+		plugin.Logger(ctx).Error("getIamPermissionsSnapshotUncached", "source", source, "read_error", err)
+		return nil, fmt.Errorf("failed to read iam_action_data_source %q: %v", source, err)
+	}
+
+	data, err := parseIamPermissionsSnapshot(content)
+	if err != nil {
+		plugin.Logger(ctx).Error("getIamPermissionsSnapshotUncached", "source", source, "parse_error", err)
+		return nil, fmt.Errorf("failed to parse iam_action_data_source %q: %v", source, err)
+	}
+
+	return data, nil
+}
+
+// readIamPermissionsSnapshot reads the snapshot from a http(s) URL or a local file
+func readIamPermissionsSnapshot(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return os.ReadFile(source)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// parseIamPermissionsSnapshot converts an iam_definition.json document into
+// the same structure as the built-in data
+func parseIamPermissionsSnapshot(content []byte) (ParliamentPermissions, error) {
+	var services []parliamentSnapshotService
+	if err := json.Unmarshal(content, &services); err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("snapshot does not contain any services")
+	}
+
+	permissions := make(ParliamentPermissions, 0, len(services))
+	for _, s := range services {
+		if s.Prefix == "" {
+			return nil, fmt.Errorf("service %q is missing a prefix", s.ServiceName)
+		}
+
+		service := ParliamentService{
+			Conditions:  []ParliamentCondition{},
+			Prefix:      s.Prefix,
+			Privileges:  []ParliamentPrivilege{},
+			Resources:   []ParliamentResource{},
+			ServiceName: s.ServiceName,
+		}
+		for _, c := range s.Conditions {
+			service.Conditions = append(service.Conditions, ParliamentCondition{
+				Condition:   c.Condition,
+				Description: c.Description,
+				Type:        c.Type,
+			})
+		}
+		for _, p := range s.Privileges {
+			privilege := ParliamentPrivilege{
+				AccessLevel:   p.AccessLevel,
+				Description:   p.Description,
+				Privilege:     p.Privilege,
+				ResourceTypes: []ParliamentResourceType{},
+			}
+			for _, rt := range p.ResourceTypes {
+				privilege.ResourceTypes = append(privilege.ResourceTypes, ParliamentResourceType{
+					ConditionKeys:    nonNilStrings(rt.ConditionKeys),
+					DependentActions: nonNilStrings(rt.DependentActions),
+					ResourceType:     rt.ResourceType,
+				})
+			}
+			service.Privileges = append(service.Privileges, privilege)
+		}
+		for _, r := range s.Resources {
+			service.Resources = append(service.Resources, ParliamentResource{
+				Arn:           r.Arn,
+				ConditionKeys: nonNilStrings(r.ConditionKeys),
+				Resource:      r.Resource,
+			})
+		}
+		permissions = append(permissions, service)
+	}
+
+	return permissions, nil
+}
+
+// nonNilStrings keeps the snapshot data consistent with the generated data,
+// which always uses empty slices rather than nil
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,109 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseIamPermissionsSnapshot(t *testing.T) {
+	snapshot := `[
+		{
+			"prefix": "s3",
+			"service_name": "Amazon S3",
+			"privileges": [
+				{
+					"access_level": "Read",
+					"description": "Grants permission to retrieve objects from Amazon S3",
+					"privilege": "GetObject",
+					"resource_types": [
+						{
+							"condition_keys": [],
+							"dependent_actions": [],
+							"resource_type": "object*"
+						},
+						{
+							"condition_keys": ["s3:DataAccessPointArn"],
+							"resource_type": ""
+						}
+					]
+				}
+			],
+			"resources": [
+				{
+					"arn": "arn:${Partition}:s3:::${BucketName}/${ObjectName}",
+					"condition_keys": [],
+					"resource": "object"
+				}
+			],
+			"conditions": [
+				{
+					"condition": "s3:DataAccessPointArn",
+					"description": "Filters access by an access point Amazon Resource Name (ARN)",
+					"type": "String"
+				}
+			]
+		}
+	]`
+
+	expected := ParliamentPermissions{
+		ParliamentService{
+			Conditions: []ParliamentCondition{
+				{
+					Condition:   "s3:DataAccessPointArn",
+					Description: "Filters access by an access point Amazon Resource Name (ARN)",
+					Type:        "String",
+				},
+			},
+			Prefix: "s3",
+			Privileges: []ParliamentPrivilege{
+				{
+					AccessLevel: "Read",
+					Description: "Grants permission to retrieve objects from Amazon S3",
+					Privilege:   "GetObject",
+					ResourceTypes: []ParliamentResourceType{
+						{
+							ConditionKeys:    []string{},
+							DependentActions: []string{},
+							ResourceType:     "object*",
+						},
+						{
+							ConditionKeys:    []string{"s3:DataAccessPointArn"},
+							DependentActions: []string{},
+							ResourceType:     "",
+						},
+					},
+				},
+			},
+			Resources: []ParliamentResource{
+				{
+					Arn:           "arn:${Partition}:s3:::${BucketName}/${ObjectName}",
+					ConditionKeys: []string{},
+					Resource:      "object",
+				},
+			},
+			ServiceName: "Amazon S3",
+		},
+	}
+
+	permissions, err := parseIamPermissionsSnapshot([]byte(snapshot))
+	if err != nil {
+		t.Fatalf("parseIamPermissionsSnapshot failed: %v", err)
+	}
+	if !reflect.DeepEqual(permissions, expected) {
+		t.Errorf("parseIamPermissionsSnapshot:\nexpected: %+v\nactual:   %+v", expected, permissions)
+	}
+}
+
+func TestParseIamPermissionsSnapshotInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"not json":       `not json`,
+		"empty":          `[]`,
+		"missing prefix": `[{"service_name": "Amazon S3", "privileges": []}]`,
+	}
+
+	for name, snapshot := range testCases {
+		if _, err := parseIamPermissionsSnapshot([]byte(snapshot)); err == nil {
+			t.Errorf("%s: expected an error, got nil", name)
+		}
+	}
+}
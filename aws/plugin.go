@@ -48,6 +48,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 		ConnectionConfigSchema: &plugin.ConnectionConfigSchema{
 			NewInstance: ConfigInstance,
 		},
+		ConnectionConfigChangedFunc: connectionConfigChanged,
 		RateLimiters: []*rate_limiter.Definition{
 			{
 				Name:       "aws_servicequotas_list_aws_default_service_quotas",
@@ -75,6 +76,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			},
 		},
 		TableMap: map[string]*plugin.Table{
+			"aws_abac_readiness":                                           tableAwsAbacReadiness(ctx),
 			"aws_accessanalyzer_analyzer":                                  tableAwsAccessAnalyzer(ctx),
 			"aws_accessanalyzer_finding":                                   tableAwsAccessAnalyzerFinding(ctx),
 			"aws_account":                                                  tableAwsAccount(ctx),
@@ -98,8 +100,10 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_appautoscaling_policy":                                    tableAwsAppAutoScalingPolicy(ctx),
 			"aws_appautoscaling_target":                                    tableAwsAppAutoScalingTarget(ctx),
 			"aws_appconfig_application":                                    tableAwsAppConfigApplication(ctx),
+			"aws_appflow_flow":                                             tableAwsAppFlowFlow(ctx),
 			"aws_appstream_fleet":                                          tableAwsAppStreamFleet(ctx),
 			"aws_appstream_image":                                          tableAwsAppStreamImage(ctx),
+			"aws_appstream_stack":                                          tableAwsAppStreamStack(ctx),
 			"aws_appsync_graphql_api":                                      tableAwsAppsyncGraphQLApi(ctx),
 			"aws_athena_query_execution":                                   tableAwsAthenaQueryExecution(ctx),
 			"aws_athena_workgroup":                                         tableAwsAthenaWorkGroup(ctx),
@@ -118,6 +122,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_backup_selection":                                         tableAwsBackupSelection(ctx),
 			"aws_backup_vault":                                             tableAwsBackupVault(ctx),
 			"aws_backup_job":                                               tableAwsBackupJob(ctx),
+			"aws_budget_vs_actual_by_tag":                                  tableAwsBudgetVsActualByTag(ctx),
 			"aws_cloudcontrol_resource":                                    tableAwsCloudControlResource(ctx),
 			"aws_cloudformation_stack":                                     tableAwsCloudFormationStack(ctx),
 			"aws_cloudformation_stack_resource":                            tableAwsCloudFormationStackResource(ctx),
@@ -163,8 +168,10 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_config_aggregate_authorization":                           tableAwsConfigAggregateAuthorization(ctx),
 			"aws_config_configuration_recorder":                            tableAwsConfigConfigurationRecorder(ctx),
 			"aws_config_conformance_pack":                                  tableAwsConfigConformancePack(ctx),
+			"aws_config_resource_history":                                  tableAwsConfigResourceHistory(ctx),
 			"aws_config_retention_configuration":                           tableAwsConfigRetentionConfiguration(ctx),
 			"aws_config_rule":                                              tableAwsConfigRule(ctx),
+			"aws_connection_check":                                         tableAwsConnectionCheck(ctx),
 			"aws_cost_by_account_daily":                                    tableAwsCostByLinkedAccountDaily(ctx),
 			"aws_cost_by_account_monthly":                                  tableAwsCostByLinkedAccountMonthly(ctx),
 			"aws_cost_by_record_type_daily":                                tableAwsCostByRecordTypeDaily(ctx),
@@ -181,6 +188,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_dax_parameter":                                            tableAwsDaxParameter(ctx),
 			"aws_dax_parameter_group":                                      tableAwsDaxParameterGroup(ctx),
 			"aws_dax_subnet_group":                                         tableAwsDaxSubnetGroup(ctx),
+			"aws_deprecated_api_usage":                                     tableAwsDeprecatedApiUsage(ctx),
 			"aws_directory_service_certificate":                            tableAwsDirectoryServiceCertificate(ctx),
 			"aws_directory_service_directory":                              tableAwsDirectoryServiceDirectory(ctx),
 			"aws_directory_service_log_subscription":                       tableAwsDirectoryServiceLogSubscription(ctx),
@@ -195,7 +203,9 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_drs_job":                                                  tableAwsDRSJob(ctx),
 			"aws_drs_recovery_instance":                                    tableAwsDRSRecoveryInstance(ctx),
 			"aws_drs_recovery_snapshot":                                    tableAwsDRSRecoverySnapshot(ctx),
+			"aws_drs_replication_configuration_template":                   tableAwsDRSReplicationConfigurationTemplate(ctx),
 			"aws_drs_source_server":                                        tableAwsDRSSourceServer(ctx),
+			"aws_drs_source_server_replication_configuration":              tableAwsDRSSourceServerReplicationConfiguration(ctx),
 			"aws_dynamodb_backup":                                          tableAwsDynamoDBBackup(ctx),
 			"aws_dynamodb_global_table":                                    tableAwsDynamoDBGlobalTable(ctx),
 			"aws_dynamodb_metric_account_provisioned_read_capacity_util":   tableAwsDynamoDBMetricAccountProvisionedReadCapacityUtilization(ctx),
@@ -222,6 +232,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ec2_gateway_load_balancer":                                tableAwsEc2GatewayLoadBalancer(ctx),
 			"aws_ec2_instance":                                             tableAwsEc2Instance(ctx),
 			"aws_ec2_instance_availability":                                tableAwsInstanceAvailability(ctx),
+			"aws_ec2_instance_imdsv1_usage":                                tableAwsEc2InstanceImdsv1Usage(ctx),
 			"aws_ec2_instance_metric_cpu_utilization":                      tableAwsEc2InstanceMetricCpuUtilization(ctx),
 			"aws_ec2_instance_metric_cpu_utilization_daily":                tableAwsEc2InstanceMetricCpuUtilizationDaily(ctx),
 			"aws_ec2_instance_metric_cpu_utilization_hourly":               tableAwsEc2InstanceMetricCpuUtilizationHourly(ctx),
@@ -282,6 +293,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_elasticache_redis_metric_new_connections_hourly":          tableAwsElasticacheRedisMetricNewConnectionsHourly(ctx),
 			"aws_elasticache_replication_group":                            tableAwsElastiCacheReplicationGroup(ctx),
 			"aws_elasticache_reserved_cache_node":                          tableAwsElastiCacheReservedCacheNode(ctx),
+			"aws_elasticache_serverless_cache":                             tableAwsElastiCacheServerlessCache(ctx),
 			"aws_elasticache_subnet_group":                                 tableAwsElastiCacheSubnetGroup(ctx),
 			"aws_elasticsearch_domain":                                     tableAwsElasticsearchDomain(ctx),
 			"aws_emr_block_public_access_configuration":                    tableAwsEmrBlockPublicAccessConfiguration(ctx),
@@ -291,7 +303,9 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_emr_instance_fleet":                                       tableAwsEmrInstanceFleet(ctx),
 			"aws_emr_instance_group":                                       tableAwsEmrInstanceGroup(ctx),
 			"aws_emr_security_configuration":                               tableAwsEmrSecurityConfiguration(ctx),
+			"aws_eventbridge_archive":                                      tableAwsEventBridgeArchive(ctx),
 			"aws_eventbridge_bus":                                          tableAwsEventBridgeBus(ctx),
+			"aws_eventbridge_replay":                                       tableAwsEventBridgeReplay(ctx),
 			"aws_eventbridge_rule":                                         tableAwsEventBridgeRule(ctx),
 			"aws_fms_app_list":                                             tableAwsFMSAppList(ctx),
 			"aws_fms_policy":                                               tableAwsFMSPolicy(ctx),
@@ -329,6 +343,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_iam_policy":                                               tableAwsIamPolicy(ctx),
 			"aws_iam_policy_attachment":                                    tableAwsIamPolicyAttachment(ctx),
 			"aws_iam_policy_simulator":                                     tableAwsIamPolicySimulator(ctx),
+			"aws_iam_principal_without_boundary":                           tableAwsIamPrincipalWithoutBoundary(ctx),
 			"aws_iam_role":                                                 tableAwsIamRole(ctx),
 			"aws_iam_saml_provider":                                        tableAwsIamSamlProvider(ctx),
 			"aws_iam_server_certificate":                                   tableAwsIamServerCertificate(ctx),
@@ -375,6 +390,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_mq_broker":                                                tableAwsMQBroker(ctx),
 			"aws_msk_cluster":                                              tableAwsMSKCluster(ctx),
 			"aws_msk_serverless_cluster":                                   tableAwsMSKServerlessCluster(ctx),
+			"aws_mwaa_environment":                                         tableAwsMWAAEnvironment(ctx),
 			"aws_neptune_db_cluster":                                       tableAwsNeptuneDBCluster(ctx),
 			"aws_neptune_db_cluster_snapshot":                              tableAwsNeptuneDBClusterSnapshot(ctx),
 			"aws_networkfirewall_firewall":                                 tableAwsNetworkFirewallFirewall(ctx),
@@ -394,6 +410,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_pricing_service_attribute":                                tableAwsPricingServiceAttribute(ctx),
 			"aws_ram_principal_association":                                tableAwsRAMPrincipalAssociation(ctx),
 			"aws_ram_resource_association":                                 tableAwsRAMResourceAssociation(ctx),
+			"aws_rds_db_ca_certificate_rotation":                           tableAwsRDSDBCACertificateRotation(ctx),
 			"aws_rds_db_cluster":                                           tableAwsRDSDBCluster(ctx),
 			"aws_rds_db_cluster_parameter_group":                           tableAwsRDSDBClusterParameterGroup(ctx),
 			"aws_rds_db_cluster_snapshot":                                  tableAwsRDSDBClusterSnapshot(ctx),
@@ -428,6 +445,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_redshiftserverless_namespace":                             tableAwsRedshiftServerlessNamespace(ctx),
 			"aws_redshiftserverless_workgroup":                             tableAwsRedshiftServerlessWorkgroup(ctx),
 			"aws_region":                                                   tableAwsRegion(ctx),
+			"aws_resource":                                                 tableAwsResource(ctx),
 			"aws_resource_explorer_index":                                  tableAWSResourceExplorerIndex(ctx),
 			"aws_resource_explorer_search":                                 tableAWSResourceExplorerSearch(ctx),
 			"aws_resource_explorer_supported_resource_type":                tableAWSResourceExplorerSupportedResourceType(ctx),
@@ -448,6 +466,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_s3_bucket_intelligent_tiering_configuration":              tableAwsS3BucketIntelligentTieringConfiguration(ctx),
 			"aws_s3_multi_region_access_point":                             tableAwsS3MultiRegionAccessPoint(ctx),
 			"aws_s3_object":                                                tableAwsS3Object(ctx),
+			"aws_s3_object_public_sample":                                  tableAwsS3ObjectPublicSample(ctx),
 			"aws_s3_object_version":                                        tableAwsS3ObjectVersion(ctx),
 			"aws_sagemaker_app":                                            tableAwsSageMakerApp(ctx),
 			"aws_sagemaker_domain":                                         tableAwsSageMakerDomain(ctx),
@@ -466,8 +485,10 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_securityhub_standards_control":                            tableAwsSecurityHubStandardsControl(ctx),
 			"aws_securityhub_standards_subscription":                       tableAwsSecurityHubStandardsSubscription(ctx),
 			"aws_securitylake_data_lake":                                   tableAwsSecurityLakeDataLake(ctx),
+			"aws_securitylake_log_source":                                  tableAwsSecurityLakeLogSource(ctx),
 			"aws_securitylake_subscriber":                                  tableAwsSecurityLakeSubscriber(ctx),
 			"aws_serverlessapplicationrepository_application":              tableAwsServerlessApplicationRepositoryApplication(ctx),
+			"aws_service_endpoint":                                         tableAwsServiceEndpoint(ctx),
 			"aws_servicecatalog_portfolio":                                 tableAwsServicecatalogPortfolio(ctx),
 			"aws_servicecatalog_product":                                   tableAwsServicecatalogProduct(ctx),
 			"aws_servicecatalog_provisioned_product":                       tableAwsServicecatalogProvisionedProduct(ctx),
@@ -496,6 +517,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ssm_maintenance_window":                                   tableAwsSSMMaintenanceWindow(ctx),
 			"aws_ssm_managed_instance":                                     tableAwsSSMManagedInstance(ctx),
 			"aws_ssm_managed_instance_compliance":                          tableAwsSSMManagedInstanceCompliance(ctx),
+			"aws_ssm_managed_instance_coverage":                            tableAwsSSMManagedInstanceCoverage(ctx),
 			"aws_ssm_managed_instance_patch_state":                         tableAwsSSMManagedInstancePatchState(ctx),
 			"aws_ssm_parameter":                                            tableAwsSSMParameter(ctx),
 			"aws_ssm_patch_baseline":                                       tableAwsSSMPatchBaseline(ctx),
@@ -506,6 +528,7 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_ssoadmin_permission_set":                                  tableAwsSsoAdminPermissionSet(ctx),
 			"aws_sts_caller_identity":                                      tableAwsSTSCallerIdentity(ctx),
 			"aws_tagging_resource":                                         tableAwsTaggingResource(ctx),
+			"aws_tls_policy_detail":                                        tableAwsTlsPolicyDetail(ctx),
 			"aws_transfer_server":                                          tableAwsTransferServer(ctx),
 			"aws_transfer_user":                                            tableAwsTransferUser(ctx),
 			"aws_trusted_advisor_check_summary":                            tableAwsTrustedAdvisorCheckSummary(ctx),
@@ -560,11 +583,16 @@ func Plugin(ctx context.Context) *plugin.Plugin {
 			"aws_wellarchitected_share_invitation":                         tableAwsWellArchitectedShareInvitation(ctx),
 			"aws_wellarchitected_workload":                                 tableAwsWellArchitectedWorkload(ctx),
 			"aws_wellarchitected_workload_share":                           tableAwsWellArchitectedWorkloadShare(ctx),
+			"aws_wellknown_public_account":                                 tableAwsWellKnownPublicAccount(ctx),
 			"aws_workspaces_directory":                                     tableAwsWorkspacesDirectory(ctx),
 			"aws_workspaces_workspace":                                     tableAwsWorkspace(ctx),
 		},
 	}
 
+	addRedactionTransforms(p)
+
+	enableCacheInvalidation(p)
+
 	return p
 }
 
@@ -0,0 +1,144 @@
+package aws
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
+)
+
+// redactedValue replaces the value of redacted STRING and JSON columns. Columns
+// of other types are returned as null.
+const redactedValue = "[REDACTED]"
+
+// Column values are produced by transforms, which do not have access to the
+// connection (and so the connection config) of the query. The "redact_columns"
+// patterns are therefore registered per connection by setUpConnection, when a
+// connection is first used and whenever its config changes, and the redaction
+// transform applies the patterns of every connection served by this plugin
+// instance. In a plugin instance serving several connections, patterns set on
+// any of them apply to all of them, which errs on the side of hiding too much
+// rather than too little.
+var redactionPatterns = &redactionPatternRegistry{byConnection: map[string][]string{}}
+
+type redactionPatternRegistry struct {
+	mu           sync.RWMutex
+	byConnection map[string][]string
+	patterns     []string
+	// match results for "<table>.<column>", reset when the patterns change
+	matches sync.Map
+}
+
+// register sets the redaction patterns for a connection
+func (r *redactionPatternRegistry) register(connectionName string, patterns []string) {
+	r.mu.RLock()
+	current, ok := r.byConnection[connectionName]
+	r.mu.RUnlock()
+	if ok && strings.Join(current, ",") == strings.Join(patterns, ",") {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(patterns) == 0 {
+		delete(r.byConnection, connectionName)
+	} else {
+		r.byConnection[connectionName] = patterns
+	}
+
+	var all []string
+	for _, p := range r.byConnection {
+		all = append(all, p...)
+	}
+	all = uniqueStrings(all)
+	sort.Strings(all)
+	r.patterns = all
+	r.matches = sync.Map{}
+}
+
+// shouldRedact returns true if the column matches any registered pattern.
+// Patterns use wildcard.MatchGlob syntax and are matched against both the column name
+// (e.g. "user_data") and the qualified name (e.g. "aws_lambda_function.environment_variables").
+func (r *redactionPatternRegistry) shouldRedact(tableName string, columnName string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.patterns) == 0 {
+		return false
+	}
+
+	qualifiedName := tableName + "." + columnName
+	if matched, ok := r.matches.Load(qualifiedName); ok {
+		return matched.(bool)
+	}
+
+	matched := false
+	for _, pattern := range r.patterns {
+		if wildcard.MatchGlob(pattern, columnName) || wildcard.MatchGlob(pattern, qualifiedName) {
+			matched = true
+			break
+		}
+	}
+	r.matches.Store(qualifiedName, matched)
+	return matched
+}
+
+type redactionParam struct {
+	Table string
+	Type  proto.ColumnType
+}
+
+// addRedactionTransforms appends the redaction transform to every column of
+// every table. Columns without a transform are given the table (or plugin)
+// default transform first, so their values are unchanged when not redacted.
+func addRedactionTransforms(p *plugin.Plugin) {
+	for tableName, table := range p.TableMap {
+		defaultTransform := table.DefaultTransform
+		if defaultTransform == nil {
+			defaultTransform = p.DefaultTransform
+		}
+
+		for _, column := range table.Columns {
+			columnTransform := column.Transform
+			if columnTransform == nil {
+				columnTransform = defaultTransform
+			}
+
+			// copy the transform calls, since transforms (e.g. the plugin
+			// default) may be shared between columns
+			calls := make([]*transform.TransformCall, 0, len(columnTransform.Transforms)+1)
+			calls = append(calls, columnTransform.Transforms...)
+			calls = append(calls, &transform.TransformCall{
+				Transform: redactColumnValue,
+				Param:     redactionParam{Table: tableName, Type: column.Type},
+			})
+			column.Transform = &transform.ColumnTransforms{Transforms: calls}
+		}
+	}
+}
+
+//// TRANSFORM FUNCTIONS
+
+// redactColumnValue replaces the column value if it matches a "redact_columns" pattern
+func redactColumnValue(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.Value == nil {
+		return nil, nil
+	}
+
+	param := d.Param.(redactionParam)
+	if !redactionPatterns.shouldRedact(param.Table, d.ColumnName) {
+		return d.Value, nil
+	}
+
+	switch param.Type {
+	case proto.ColumnType_STRING, proto.ColumnType_JSON:
+		return redactedValue, nil
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,63 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestRedactionPatternRegistry(t *testing.T) {
+	registry := &redactionPatternRegistry{byConnection: map[string][]string{}}
+	if registry.shouldRedact("aws_ec2_instance", "user_data") {
+		t.Errorf("expected no redaction without patterns")
+	}
+
+	registry.register("aws_dev", []string{"user_data", "aws_lambda_function.environment_*"})
+	registry.register("aws_prod", []string{"*secret*"})
+
+	testCases := []struct {
+		table    string
+		column   string
+		expected bool
+	}{
+		{"aws_ec2_instance", "user_data", true},
+		{"aws_ec2_launch_template_version", "user_data", true},
+		{"aws_lambda_function", "environment_variables", true},
+		{"aws_lambda_version", "environment_variables", false},
+		{"aws_secretsmanager_secret", "secret_string", true},
+		{"aws_ec2_instance", "instance_id", false},
+	}
+	for _, tc := range testCases {
+		if actual := registry.shouldRedact(tc.table, tc.column); actual != tc.expected {
+			t.Errorf("shouldRedact(%q, %q): expected %t, got %t", tc.table, tc.column, tc.expected, actual)
+		}
+	}
+
+	// clearing the patterns of a connection must also clear cached matches
+	registry.register("aws_dev", nil)
+	if registry.shouldRedact("aws_ec2_instance", "user_data") {
+		t.Errorf("expected user_data not to be redacted after its pattern was removed")
+	}
+	if !registry.shouldRedact("aws_secretsmanager_secret", "secret_string") {
+		t.Errorf("expected secret_string to still be redacted")
+	}
+}
+
+func TestSetUpConnectionRegistersRedactionPatterns(t *testing.T) {
+	connection := &plugin.Connection{
+		Name:   "aws_redaction_test",
+		Config: awsConfig{RedactColumns: []string{"aws_redaction_test_table.*"}},
+	}
+	defer redactionPatterns.register(connection.Name, nil)
+
+	GetConfig(connection)
+	if !redactionPatterns.shouldRedact("aws_redaction_test_table", "secret") {
+		t.Errorf("expected the patterns to be registered when the connection is first used")
+	}
+
+	connection.Config = awsConfig{}
+	setUpConnection(connection, GetConfig(connection))
+	if redactionPatterns.shouldRedact("aws_redaction_test_table", "secret") {
+		t.Errorf("expected the patterns to be removed when the config changes")
+	}
+}
@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+	"github.com/turbot/go-kit/helpers"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Reasons a region is not queried for a connection, as shown in the
+// skip_reason column of aws_region.
+const (
+	regionSkipReasonNotInConnectionRegions = "not_in_connection_regions"
+	regionSkipReasonNotOptedIn             = "not_opted_in"
+	regionSkipReasonStsEndpointDisabled    = "sts_endpoint_disabled"
+)
+
+// Error codes returned when the credentials are valid but cannot be used in
+// the region, either because the region is not enabled for the account or
+// because the STS regional endpoint is deactivated.
+var regionAccessErrorCodes = []string{
+	"InvalidClientTokenId",
+	"UnrecognizedClient",
+	"UnrecognizedClientException",
+	"RegionDisabledException",
+}
+
+func isRegionAccessError(err error) bool {
+	var ae smithy.APIError
+	if errors.As(err, &ae) {
+		return helpers.StringSliceContains(regionAccessErrorCodes, ae.ErrorCode())
+	}
+	return false
+}
+
+// shouldSkipRegionForError returns true if err was caused by the credentials
+// not being usable in the query region. Those regions are skipped with a
+// warning, rather than failing queries across all regions.
+func shouldSkipRegionForError(ctx context.Context, d *plugin.QueryData, err error) bool {
+	if !isRegionAccessError(err) {
+		return false
+	}
+	region := d.EqualsQualString(matrixKeyRegion)
+	if region == "" {
+		return false
+	}
+
+	reason, probeErr := getRegionSkipReason(ctx, d, region)
+	if probeErr != nil || reason == "" {
+		return false
+	}
+	plugin.Logger(ctx).Warn("shouldSkipRegionForError", "connection_name", d.Connection.Name, "region", region, "skip_reason", reason, "error", err)
+	return true
+}
+
+// getRegionSkipReason checks whether the connection credentials can be used
+// in the region, returning the reason they cannot or an empty string. A
+// region is only reported as skipped if the credentials work in the default
+// region, so that invalid credentials are still reported as errors.
+func getRegionSkipReason(ctx context.Context, d *plugin.QueryData, region string) (string, error) {
+	defaultRegion, err := getDefaultRegion(ctx, d, nil)
+	if err != nil {
+		return "", err
+	}
+	if region == defaultRegion {
+		return "", nil
+	}
+
+	reason, err := getRegionAccessReasonCached(ctx, d, &plugin.HydrateData{Item: region})
+	if err != nil || reason.(string) == "" {
+		return "", err
+	}
+	defaultReason, err := getRegionAccessReasonCached(ctx, d, &plugin.HydrateData{Item: defaultRegion})
+	if err != nil || defaultReason.(string) != "" {
+		return "", err
+	}
+	return reason.(string), nil
+}
+
+// Cached form of getRegionAccessReason, per connection and region.
+var getRegionAccessReasonCached = plugin.HydrateFunc(getRegionAccessReasonUncached).Memoize(memoize.WithCacheKeyFunction(getRegionAccessReasonCacheKey))
+
+func getRegionAccessReasonCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := h.Item.(string)
+	key := fmt.Sprintf("getRegionAccessReason-%s", region)
+	return key, nil
+}
+
+// getRegionAccessReasonUncached calls GetCallerIdentity on the regional STS
+// endpoint of the region and classifies any region access error.
+func getRegionAccessReasonUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := h.Item.(string)
+
+	cfg, err := getClientWithMaxRetries(ctx, d, region, 2, 25*time.Millisecond)
+	if err != nil {
+		return nil, err
+	}
+	svc := sts.NewFromConfig(*cfg)
+
+	_, err = svc.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err == nil {
+		return "", nil
+	}
+	if !isRegionAccessError(err) {
+		plugin.Logger(ctx).Error("getRegionAccessReasonUncached", "connection_name", d.Connection.Name, "region", region, "api_error", err)
+		return nil, err
+	}
+
+	var ae smithy.APIError
+	if errors.As(err, &ae) && ae.ErrorCode() == "RegionDisabledException" {
+		return regionSkipReasonStsEndpointDisabled, nil
+	}
+	return regionSkipReasonNotOptedIn, nil
+}
@@ -0,0 +1,257 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	"github.com/aws/aws-sdk-go-v2/service/route53domains"
+	"github.com/aws/smithy-go"
+
+	route53domainsEndpoint "github.com/aws/aws-sdk-go/service/route53domains"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Sources of the name servers a hosted zone is delegated to by its parent
+const (
+	route53ParentSourceHostedZone       = "hosted_zone"
+	route53ParentSourceRegisteredDomain = "registered_domain"
+)
+
+// route53ParentDelegation is the NS record set for a hosted zone in its
+// parent, and whether it matches the zone's delegation set
+type route53ParentDelegation struct {
+	NameServers  []string
+	Source       string
+	ParentZoneId *string
+	Matches      bool
+}
+
+// Every hosted zone is checked against the other zones of the account, so they
+// are only listed once per connection
+var getRoute53PublicHostedZoneIdsCached = plugin.HydrateFunc(getRoute53PublicHostedZoneIdsUncached).Memoize()
+
+// getRoute53PublicHostedZoneIdsUncached returns the IDs of the public hosted
+// zones of the account, keyed by normalized zone name. A name can have more
+// than one zone.
+func getRoute53PublicHostedZoneIdsUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := Route53Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_zone.getRoute53PublicHostedZoneIdsUncached", "client_error", err)
+		return nil, err
+	}
+
+	zoneIds := map[string][]string{}
+	paginator := route53.NewListHostedZonesPaginator(svc, &route53.ListHostedZonesInput{}, func(o *route53.ListHostedZonesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_route53_zone.getRoute53PublicHostedZoneIdsUncached", "api_error", err)
+			return nil, err
+		}
+		for _, zone := range output.HostedZones {
+			if zone.Config != nil && zone.Config.PrivateZone {
+				continue
+			}
+			name := normalizeRoute53Name(*zone.Name)
+			zoneIds[name] = append(zoneIds[name], *zone.Id)
+		}
+	}
+
+	return zoneIds, nil
+}
+
+// getHostedZoneParentDelegation looks up the NS records for a public hosted
+// zone in its parent. The parent is the nearest ancestor zone in the account
+// with an NS record set for the zone, or, for a domain registered with Route
+// 53, the registration's name servers. Zones delegated from anywhere else
+// return nil.
+func getHostedZoneParentDelegation(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	hostedZone := h.Item.(HostedZoneResult)
+
+	// Private zones are not delegated
+	if hostedZone.Config != nil && hostedZone.Config.PrivateZone {
+		return nil, nil
+	}
+
+	// Listed zones do not include the delegation set, getHostedZone does
+	if hostedZone.DelegationSet == nil {
+		if item, ok := h.HydrateResults["getHostedZone"].(HostedZoneResult); ok {
+			hostedZone = item
+		}
+	}
+	var delegationSet []string
+	if hostedZone.DelegationSet != nil {
+		delegationSet = hostedZone.DelegationSet.NameServers
+	}
+
+	zoneIds, err := getRoute53PublicHostedZoneIdsCached(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	svc, err := Route53Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_zone.getHostedZoneParentDelegation", "client_error", err)
+		return nil, err
+	}
+
+	zoneName := normalizeRoute53Name(*hostedZone.Name)
+	for _, parentName := range route53ParentDomains(zoneName) {
+		for _, parentZoneId := range zoneIds.(map[string][]string)[parentName] {
+			nameServers, err := getRoute53NSRecordValues(ctx, d, svc, parentZoneId, zoneName)
+			if err != nil {
+				return nil, err
+			}
+			if nameServers == nil {
+				continue
+			}
+			return route53ParentDelegation{
+				NameServers:  nameServers,
+				Source:       route53ParentSourceHostedZone,
+				ParentZoneId: aws.String(strings.TrimPrefix(parentZoneId, "/hostedzone/")),
+				Matches:      route53NameServersMatch(nameServers, delegationSet),
+			}, nil
+		}
+	}
+
+	nameServers, err := getRoute53DomainNameServers(ctx, d, zoneName)
+	if err != nil {
+		return nil, err
+	}
+	if nameServers == nil {
+		return nil, nil
+	}
+	return route53ParentDelegation{
+		NameServers: nameServers,
+		Source:      route53ParentSourceRegisteredDomain,
+		Matches:     route53NameServersMatch(nameServers, delegationSet),
+	}, nil
+}
+
+// getRoute53NSRecordValues returns the values of the NS record set for name in
+// the hosted zone, or nil if the zone has none
+func getRoute53NSRecordValues(ctx context.Context, d *plugin.QueryData, svc *route53.Client, zoneId string, name string) ([]string, error) {
+	d.WaitForListRateLimit(ctx)
+
+	output, err := svc.ListResourceRecordSets(ctx, &route53.ListResourceRecordSetsInput{
+		HostedZoneId:    aws.String(zoneId),
+		StartRecordName: aws.String(name),
+		StartRecordType: "NS",
+		MaxItems:        aws.Int32(1),
+	})
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchHostedZone" {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("aws_route53_zone.getRoute53NSRecordValues", "api_error", err)
+		return nil, err
+	}
+
+	for _, recordSet := range output.ResourceRecordSets {
+		if recordSet.Type != "NS" || normalizeRoute53Name(*recordSet.Name) != name {
+			continue
+		}
+		values := []string{}
+		for _, record := range recordSet.ResourceRecords {
+			values = append(values, *record.Value)
+		}
+		return values, nil
+	}
+	return nil, nil
+}
+
+// getRoute53DomainNameServers returns the name servers of the domain if it is
+// registered with Route 53 in this account, or nil if it is not, or if the
+// registrations cannot be read
+func getRoute53DomainNameServers(ctx context.Context, d *plugin.QueryData, domainName string) ([]string, error) {
+	// Route 53 Domains is only available in the commercial partition, not
+	// e.g. in GovCloud or China
+	regions, err := listRegionsForService(ctx, d, route53domainsEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 0 {
+		return nil, nil
+	}
+
+	svc, err := Route53DomainsClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_route53_zone.getRoute53DomainNameServers", "client_error", err)
+		return nil, err
+	}
+
+	output, err := svc.GetDomainDetail(ctx, &route53domains.GetDomainDetailInput{
+		DomainName: aws.String(domainName),
+	})
+	if err != nil {
+		// The domain is not registered in this account, or the registrations
+		// are not visible to the caller
+		var ae smithy.APIError
+		if errors.As(err, &ae) && helpers.StringSliceContains([]string{"InvalidInput", "UnsupportedTLD", "AccessDeniedException"}, ae.ErrorCode()) {
+			return nil, nil
+		}
+		plugin.Logger(ctx).Error("aws_route53_zone.getRoute53DomainNameServers", "api_error", err)
+		return nil, err
+	}
+
+	nameServers := []string{}
+	for _, nameServer := range output.Nameservers {
+		nameServers = append(nameServers, *nameServer.Name)
+	}
+	return nameServers, nil
+}
+
+// normalizeRoute53Name lower cases a domain name and removes the trailing dot,
+// e.g. "Example.com." becomes "example.com"
+func normalizeRoute53Name(name string) string {
+	return strings.TrimSuffix(strings.ToLower(name), ".")
+}
+
+// route53ParentDomains returns the ancestors of a normalized domain name,
+// nearest first, e.g. "a.example.com" gives "example.com" and "com"
+func route53ParentDomains(name string) []string {
+	var parents []string
+	for {
+		_, parent, found := strings.Cut(name, ".")
+		if !found || parent == "" {
+			return parents
+		}
+		parents = append(parents, parent)
+		name = parent
+	}
+}
+
+// route53NameServersMatch reports whether both lists contain the same name
+// servers, ignoring order, case and trailing dots
+func route53NameServersMatch(a []string, b []string) bool {
+	normalize := func(nameServers []string) []string {
+		normalized := []string{}
+		for _, nameServer := range nameServers {
+			normalized = append(normalized, normalizeRoute53Name(nameServer))
+		}
+		sort.Strings(normalized)
+		return uniqueStrings(normalized)
+	}
+
+	normalizedA, normalizedB := normalize(a), normalize(b)
+	if len(normalizedA) == 0 || len(normalizedA) != len(normalizedB) {
+		return false
+	}
+	for i := range normalizedA {
+		if normalizedA[i] != normalizedB[i] {
+			return false
+		}
+	}
+	return true
+}
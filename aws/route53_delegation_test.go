@@ -0,0 +1,41 @@
+package aws
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRoute53ParentDomains(t *testing.T) {
+	cases := map[string][]string{
+		"a.b.example.com": {"b.example.com", "example.com", "com"},
+		"example.com":     {"com"},
+		"com":             nil,
+	}
+	for name, want := range cases {
+		if got := route53ParentDomains(name); !reflect.DeepEqual(got, want) {
+			t.Errorf("route53ParentDomains(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestRoute53NameServersMatch(t *testing.T) {
+	delegationSet := []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.com", "ns-3.awsdns-03.net"}
+
+	cases := []struct {
+		name        string
+		nameServers []string
+		want        bool
+	}{
+		{"same order", []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.com", "ns-3.awsdns-03.net"}, true},
+		{"different order, case and trailing dots", []string{"NS-3.awsdns-03.net.", "ns-1.awsdns-01.org.", "ns-2.awsdns-02.com."}, true},
+		{"duplicates", []string{"ns-1.awsdns-01.org", "ns-1.awsdns-01.org.", "ns-2.awsdns-02.com", "ns-3.awsdns-03.net"}, true},
+		{"missing name server", []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.com"}, false},
+		{"other name server", []string{"ns-1.awsdns-01.org", "ns-2.awsdns-02.com", "ns-9.awsdns-09.net"}, false},
+		{"no name servers", []string{}, false},
+	}
+	for _, c := range cases {
+		if got := route53NameServersMatch(c.nameServers, delegationSet); got != c.want {
+			t.Errorf("%s: route53NameServersMatch(%v) = %t, want %t", c.name, c.nameServers, got, c.want)
+		}
+	}
+}
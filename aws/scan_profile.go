@@ -0,0 +1,136 @@
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"golang.org/x/time/rate"
+)
+
+// Scan profiles bundle the settings that control how hard the plugin pushes
+// the AWS APIs, so a connection to an account with tight API quotas can be
+// made gentle with a single "scan_profile" argument.
+const (
+	scanProfileDefault = "default"
+	scanProfileGentle  = "gentle"
+)
+
+type scanProfile struct {
+	MaxErrorRetryAttempts int
+	MinErrorRetryDelay    time.Duration
+	// MaxPageSize caps the page size requested by list calls, 0 for no cap
+	MaxPageSize int64
+	// RequestInterval is the minimum time between the start of two API calls
+	// to the same service in the same region, 0 for no limit
+	RequestInterval time.Duration
+}
+
+var scanProfiles = map[string]scanProfile{
+	scanProfileDefault: {
+		MaxErrorRetryAttempts: 9,
+		MinErrorRetryDelay:    25 * time.Millisecond,
+	},
+	scanProfileGentle: {
+		MaxErrorRetryAttempts: 15,
+		MinErrorRetryDelay:    100 * time.Millisecond,
+		MaxPageSize:           50,
+		RequestInterval:       250 * time.Millisecond,
+	},
+}
+
+// getScanProfile returns the scan profile selected in the connection config.
+// Explicit retry settings in the config take precedence over the profile.
+func getScanProfile(config awsConfig) scanProfile {
+	if config.ScanProfile != nil {
+		if profile, ok := scanProfiles[*config.ScanProfile]; ok {
+			return profile
+		}
+	}
+	return scanProfiles[scanProfileDefault]
+}
+
+// Request limiters are shared by every client for the same connection, region
+// and service, so parallel hydrate calls wait on each other instead of each
+// calling the API at the full rate.
+var (
+	scanProfileLimitersMutex sync.Mutex
+	scanProfileLimiters      = map[string]*rate.Limiter{}
+)
+
+func getScanProfileLimiter(key string, interval time.Duration) *rate.Limiter {
+	scanProfileLimitersMutex.Lock()
+	defer scanProfileLimitersMutex.Unlock()
+
+	limiter, ok := scanProfileLimiters[key]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Every(interval), 1)
+		scanProfileLimiters[key] = limiter
+	}
+	return limiter
+}
+
+// addScanProfileMiddleware applies the page size cap and request pacing of
+// the profile to every API call made with the config
+func addScanProfileMiddleware(cfg *aws.Config, profile scanProfile, connectionName string) {
+	if profile.MaxPageSize == 0 && profile.RequestInterval == 0 {
+		return
+	}
+
+	scanProfileMiddleware := middleware.InitializeMiddlewareFunc("SteampipeScanProfile", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (middleware.InitializeOutput, middleware.Metadata, error) {
+		if profile.MaxPageSize > 0 {
+			capPageSize(in.Parameters, profile.MaxPageSize)
+		}
+		if profile.RequestInterval > 0 {
+			key := connectionName + "/" + awsmiddleware.GetRegion(ctx) + "/" + awsmiddleware.GetServiceID(ctx)
+			if err := getScanProfileLimiter(key, profile.RequestInterval).Wait(ctx); err != nil {
+				return middleware.InitializeOutput{}, middleware.Metadata{}, err
+			}
+		}
+		return next.HandleInitialize(ctx, in)
+	})
+
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		// Added last in the initialize step, after the service metadata
+		// (service and region) has been registered on the context
+		return stack.Initialize.Add(scanProfileMiddleware, middleware.After)
+	})
+}
+
+// Input fields that set the page size of list calls across AWS services
+var pageSizeFieldNames = []string{"MaxResults", "MaxItems", "MaxRecords", "Limit", "PageSize"}
+
+// capPageSize lowers the page size set in the operation input to maxPageSize.
+// Unset page sizes are left alone, so the service default applies.
+func capPageSize(input interface{}, maxPageSize int64) {
+	v := reflect.ValueOf(input)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+	v = v.Elem()
+
+	for _, name := range pageSizeFieldNames {
+		field := v.FieldByName(name)
+		if !field.IsValid() || !field.CanSet() {
+			continue
+		}
+		if field.Kind() == reflect.Ptr {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+		}
+		switch field.Kind() {
+		case reflect.Int32, reflect.Int64:
+			if field.Int() > maxPageSize {
+				field.SetInt(maxPageSize)
+			}
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+func TestCapPageSize(t *testing.T) {
+	type listInput struct {
+		MaxResults *int32
+		MaxRecords *int32
+		MaxItems   *int32
+		Limit      int32
+		PageSize   *int64
+		NextToken  *string
+	}
+
+	input := &listInput{
+		MaxResults: aws.Int32(1000),
+		MaxRecords: aws.Int32(20),
+		Limit:      100,
+		PageSize:   aws.Int64(500),
+	}
+	capPageSize(input, 50)
+
+	if got := aws.ToInt32(input.MaxResults); got != 50 {
+		t.Errorf("MaxResults = %d, want 50", got)
+	}
+	if got := aws.ToInt32(input.MaxRecords); got != 20 {
+		t.Errorf("MaxRecords = %d, want 20", got)
+	}
+	if input.MaxItems != nil {
+		t.Errorf("MaxItems = %d, want nil", *input.MaxItems)
+	}
+	if input.Limit != 50 {
+		t.Errorf("Limit = %d, want 50", input.Limit)
+	}
+	if got := aws.ToInt64(input.PageSize); got != 50 {
+		t.Errorf("PageSize = %d, want 50", got)
+	}
+
+	// Inputs that are not struct pointers are left alone
+	capPageSize(listInput{}, 50)
+	capPageSize(nil, 50)
+}
+
+func TestGetScanProfile(t *testing.T) {
+	s := func(v string) *string { return &v }
+
+	if got := getScanProfile(awsConfig{}); got != scanProfiles[scanProfileDefault] {
+		t.Errorf("no scan_profile: got %+v, want default profile", got)
+	}
+	if got := getScanProfile(awsConfig{ScanProfile: s("gentle")}); got.RequestInterval == 0 || got.MaxPageSize == 0 {
+		t.Errorf("gentle: got %+v, want request pacing and a page size cap", got)
+	}
+}
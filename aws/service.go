@@ -25,6 +25,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/apigateway"
 	"github.com/aws/aws-sdk-go-v2/service/apigatewayv2"
 	"github.com/aws/aws-sdk-go-v2/service/appconfig"
+	"github.com/aws/aws-sdk-go-v2/service/appflow"
 	"github.com/aws/aws-sdk-go-v2/service/applicationautoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/appstream"
 	"github.com/aws/aws-sdk-go-v2/service/appsync"
@@ -32,6 +33,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/auditmanager"
 	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
+	"github.com/aws/aws-sdk-go-v2/service/budgets"
 	"github.com/aws/aws-sdk-go-v2/service/cloudcontrol"
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
@@ -94,6 +96,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/mediastore"
 	"github.com/aws/aws-sdk-go-v2/service/mgn"
 	"github.com/aws/aws-sdk-go-v2/service/mq"
+	"github.com/aws/aws-sdk-go-v2/service/mwaa"
 	"github.com/aws/aws-sdk-go-v2/service/neptune"
 	"github.com/aws/aws-sdk-go-v2/service/networkfirewall"
 	"github.com/aws/aws-sdk-go-v2/service/oam"
@@ -147,6 +150,7 @@ import (
 
 	amplifyEndpoint "github.com/aws/aws-sdk-go/service/amplify"
 	apigatewayv2Endpoint "github.com/aws/aws-sdk-go/service/apigatewayv2"
+	appflowEndpoint "github.com/aws/aws-sdk-go/service/appflow"
 	appsyncv2Endpoint "github.com/aws/aws-sdk-go/service/appsync"
 	auditmanagerEndpoint "github.com/aws/aws-sdk-go/service/auditmanager"
 	backupEndpoint "github.com/aws/aws-sdk-go/service/backup"
@@ -181,6 +185,7 @@ import (
 	mediastoreEndpoint "github.com/aws/aws-sdk-go/service/mediastore"
 	mgnEndpoint "github.com/aws/aws-sdk-go/service/mgn"
 	mqEndpoint "github.com/aws/aws-sdk-go/service/mq"
+	mwaaEndpoint "github.com/aws/aws-sdk-go/service/mwaa"
 	networkfirewallEndpoint "github.com/aws/aws-sdk-go/service/networkfirewall"
 	oamEndpoint "github.com/aws/aws-sdk-go/service/oam"
 	pinpointEndpoint "github.com/aws/aws-sdk-go/service/pinpoint"
@@ -301,6 +306,17 @@ func AppConfigClient(ctx context.Context, d *plugin.QueryData) (*appconfig.Clien
 	return appconfig.NewFromConfig(*cfg), nil
 }
 
+func AppFlowClient(ctx context.Context, d *plugin.QueryData) (*appflow.Client, error) {
+	cfg, err := getClientForQuerySupportedRegion(ctx, d, appflowEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return appflow.NewFromConfig(*cfg), nil
+}
+
 func ApplicationAutoScalingClient(ctx context.Context, d *plugin.QueryData) (*applicationautoscaling.Client, error) {
 	cfg, err := getClientForQueryRegion(ctx, d)
 	if err != nil {
@@ -366,6 +382,17 @@ func BackupClient(ctx context.Context, d *plugin.QueryData) (*backup.Client, err
 	return backup.NewFromConfig(*cfg), nil
 }
 
+func BudgetsClient(ctx context.Context, d *plugin.QueryData) (*budgets.Client, error) {
+	// Budgets is a global service that operates from a single region
+	// (budgets.amazonaws.com). Like Cost Explorer, use the client region for
+	// higher reuse.
+	cfg, err := getClientForDefaultRegion(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	return budgets.NewFromConfig(*cfg), nil
+}
+
 func CloudControlClient(ctx context.Context, d *plugin.QueryData) (*cloudcontrol.Client, error) {
 	// CloudControl returns GeneralServiceException in a lot of situations, which
 	// AWS SDK treats as retryable. This is frustrating because we end up retrying
@@ -1015,6 +1042,17 @@ func MQClient(ctx context.Context, d *plugin.QueryData) (*mq.Client, error) {
 	return mq.NewFromConfig(*cfg), nil
 }
 
+func MWAAClient(ctx context.Context, d *plugin.QueryData) (*mwaa.Client, error) {
+	cfg, err := getClientForQuerySupportedRegion(ctx, d, mwaaEndpoint.EndpointsID)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	return mwaa.NewFromConfig(*cfg), nil
+}
+
 func NeptuneClient(ctx context.Context, d *plugin.QueryData) (*neptune.Client, error) {
 	cfg, err := getClientForQueryRegion(ctx, d)
 	if err != nil {
@@ -1701,11 +1739,18 @@ func getClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 	awsSpcConfig := GetConfig(d.Connection)
 
+	// The retry options are read before the base client validates the config
+	if err := checkConnectionConfig(d.Connection.Name, awsSpcConfig); err != nil {
+		plugin.Logger(ctx).Error("getClientUncached", "connection_name", d.Connection.Name, "config_error", err)
+		return nil, err
+	}
+
 	// As per the logic used in retryRules of NewConnectionErrRetryer, default to minimum delay of 25ms and maximum
 	// number of retries as 9 (our default). The default maximum delay will not be more than approximately 3 minutes to avoid Steampipe
-	// waiting too long to return results
-	maxRetries := 9
-	var minRetryDelay time.Duration = 25 * time.Millisecond // Default minimum delay
+	// waiting too long to return results. The scan profile may raise both defaults.
+	profile := getScanProfile(awsSpcConfig)
+	maxRetries := profile.MaxErrorRetryAttempts
+	minRetryDelay := profile.MinErrorRetryDelay
 
 	// Set max retry count from config file or env variable (config file has precedence)
 	if awsSpcConfig.MaxErrorRetryAttempts != nil {
@@ -1713,7 +1758,7 @@ func getClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	} else if os.Getenv("AWS_MAX_ATTEMPTS") != "" {
 		maxRetriesEnvVar, err := strconv.Atoi(os.Getenv("AWS_MAX_ATTEMPTS"))
 		if err != nil || maxRetriesEnvVar < 1 {
-			panic("invalid value for environment variable \"AWS_MAX_ATTEMPTS\". It should be an integer value greater than or equal to 1")
+			return nil, fmt.Errorf("invalid value for environment variable \"AWS_MAX_ATTEMPTS\", it must be an integer value greater than or equal to 1")
 		}
 		maxRetries = maxRetriesEnvVar
 	}
@@ -1723,13 +1768,6 @@ func getClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		minRetryDelay = time.Duration(*awsSpcConfig.MinErrorRetryDelay) * time.Millisecond
 	}
 
-	if maxRetries < 1 {
-		panic("connection config has invalid value for \"max_error_retry_attempts\", it must be greater than or equal to 1")
-	}
-	if minRetryDelay < 1 {
-		panic("connection config has invalid value for \"min_error_retry_delay\", it must be greater than or equal to 1")
-	}
-
 	sess, err := getClientWithMaxRetries(ctx, d, region, maxRetries, minRetryDelay)
 	if err != nil {
 		plugin.Logger(ctx).Error("getClientUncached", "region", region, "err", err)
@@ -1809,6 +1847,18 @@ func getClientWithMaxRetries(ctx context.Context, d *plugin.QueryData, region st
 		}
 	}
 
+	// Emit a span per API call if telemetry is enabled for the connection
+	tp, err := getTelemetryTracerProvider(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+	if tp != nil {
+		addTelemetryMiddleware(&cfg, tp, d.Connection.Name)
+	}
+
+	// Cap page sizes and pace API calls if the scan profile asks for it
+	addScanProfileMiddleware(&cfg, getScanProfile(awsSpcConfig), d.Connection.Name)
+
 	plugin.Logger(ctx).Debug("getClientWithMaxRetries", "connection_name", d.Connection.Name, "region", region, "status", "done")
 
 	return &cfg, err
@@ -1989,6 +2039,15 @@ func getBaseClientForAccountUncached(ctx context.Context, d *plugin.QueryData, h
 
 	awsSpcConfig := GetConfig(d.Connection)
 
+	// Fail fast with the options to fix, rather than with an SDK or API error
+	if err := checkConnectionConfig(d.Connection.Name, awsSpcConfig); err != nil {
+		plugin.Logger(ctx).Error("getBaseClientForAccountUncached", "connection_name", d.Connection.Name, "config_error", err)
+		return nil, err
+	}
+	for _, warning := range configWarnings(awsSpcConfig) {
+		plugin.Logger(ctx).Warn("getBaseClientForAccountUncached", "connection_name", d.Connection.Name, "config_warning", warning)
+	}
+
 	var configOptions []func(*config.LoadOptions) error
 
 	// Note about region config: We deliberately do not set a region when
@@ -2003,11 +2062,7 @@ func getBaseClientForAccountUncached(ctx context.Context, d *plugin.QueryData, h
 		configOptions = append(configOptions, config.WithSharedConfigProfile(profile))
 	}
 
-	if awsSpcConfig.AccessKey != nil && awsSpcConfig.SecretKey == nil {
-		return nil, fmt.Errorf("partial credentials found in connection config, missing: secret_key")
-	} else if awsSpcConfig.SecretKey != nil && awsSpcConfig.AccessKey == nil {
-		return nil, fmt.Errorf("partial credentials found in connection config, missing: access_key")
-	} else if awsSpcConfig.AccessKey != nil && awsSpcConfig.SecretKey != nil {
+	if awsSpcConfig.AccessKey != nil && awsSpcConfig.SecretKey != nil {
 		plugin.Logger(ctx).Debug("getBaseClientForAccountUncached", "connection_name", d.Connection.Name, "status", "key_pair_found")
 		sessionToken := ""
 		if awsSpcConfig.SessionToken != nil {
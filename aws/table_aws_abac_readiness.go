@@ -0,0 +1,404 @@
+package aws
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamTypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/resourcegroupstaggingapi"
+
+	resourcegroupstaggingapiv1 "github.com/aws/aws-sdk-go/service/resourcegroupstaggingapi"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsAbacReadiness(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_abac_readiness",
+		Description: "AWS ABAC Readiness",
+		List: &plugin.ListConfig{
+			Hydrate: listAbacReadiness,
+			Tags:    map[string]string{"service": "tag", "action": "GetResources"},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(resourcegroupstaggingapiv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "service",
+				Description: "The service namespace of the resources, as used in their ARNs and IAM actions, e.g. ec2 or s3.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "abac_tag_keys",
+				Description: "The tag keys used for attribute-based access control (ABAC). These are the abac_tag_keys of the connection config, or if not set, the tag keys referenced by the conditions of customer managed and inline policies.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "abac_tag_keys_source",
+				Description: "Where the ABAC tag keys come from. Possible values are: config or policies.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_count",
+				Description: "The number of resources of the service in the region returned by the Resource Groups Tagging API.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "fully_tagged_resource_count",
+				Description: "The number of resources that carry all of the ABAC tag keys.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "partially_tagged_resource_count",
+				Description: "The number of resources that carry some, but not all, of the ABAC tag keys.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "tag_coverage_percent",
+				Description: "The percentage of resources that carry all of the ABAC tag keys. Null if there are no ABAC tag keys.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "missing_tag_key_counts",
+				Description: "The number of resources missing each ABAC tag key.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "policy_statement_count",
+				Description: "The number of statements in customer managed and inline policies that grant or deny actions of the service. Policies are global, so the count is the same in every region.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "resource_tag_statement_count",
+				Description: "The number of those statements with an aws:ResourceTag (or service specific ResourceTag) condition.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "principal_tag_statement_count",
+				Description: "The number of those statements with an aws:PrincipalTag condition.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "abac_statement_count",
+				Description: "The number of those statements with either an aws:ResourceTag or an aws:PrincipalTag condition.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "abac_statement_percent",
+				Description: "The percentage of those statements with an aws:ResourceTag or aws:PrincipalTag condition. Null if no statements grant or deny actions of the service.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Service"),
+			},
+		}),
+	}
+}
+
+type abacReadiness struct {
+	Service                      string
+	AbacTagKeys                  []string
+	AbacTagKeysSource            string
+	ResourceCount                int
+	FullyTaggedResourceCount     int
+	PartiallyTaggedResourceCount int
+	TagCoveragePercent           *float64
+	MissingTagKeyCounts          map[string]int
+	PolicyStatementCount         int
+	ResourceTagStatementCount    int
+	PrincipalTagStatementCount   int
+	AbacStatementCount           int
+	AbacStatementPercent         *float64
+}
+
+// abacPolicyUsage is the use of tag conditions by the customer managed and
+// inline policies in the account
+type abacPolicyUsage struct {
+	// Tag keys referenced by ResourceTag, PrincipalTag and RequestTag conditions
+	TagKeys []string
+	// Statement counts keyed by service namespace
+	Services map[string]*abacServicePolicyUsage
+}
+
+type abacServicePolicyUsage struct {
+	StatementCount             int
+	ResourceTagStatementCount  int
+	PrincipalTagStatementCount int
+	AbacStatementCount         int
+}
+
+//// LIST FUNCTION
+
+func listAbacReadiness(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	policyData, err := getAbacPolicyUsage(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_abac_readiness.listAbacReadiness", "policy_error", err)
+		return nil, err
+	}
+	policyUsage := policyData.(*abacPolicyUsage)
+
+	tagKeys := GetConfig(d.Connection).AbacTagKeys
+	tagKeysSource := "config"
+	if len(tagKeys) == 0 {
+		tagKeys = policyUsage.TagKeys
+		tagKeysSource = "policies"
+	}
+
+	// Create session
+	svc, err := ResourceGroupsTaggingClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_abac_readiness.listAbacReadiness", "connection_error", err)
+		return nil, err
+	}
+
+	input := &resourcegroupstaggingapi.GetResourcesInput{
+		ResourcesPerPage: aws.Int32(100),
+	}
+	paginator := resourcegroupstaggingapi.NewGetResourcesPaginator(svc, input, func(o *resourcegroupstaggingapi.GetResourcesPaginatorOptions) {
+		o.Limit = 100
+		o.StopOnDuplicateToken = true
+	})
+
+	rows := map[string]*abacReadiness{}
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_abac_readiness.listAbacReadiness", "api_error", err, "region", region)
+			return nil, err
+		}
+
+		for _, resource := range output.ResourceTagMappingList {
+			arnParts := strings.Split(aws.ToString(resource.ResourceARN), ":")
+			if len(arnParts) < 3 {
+				continue
+			}
+			service := arnParts[2]
+
+			row, ok := rows[service]
+			if !ok {
+				row = &abacReadiness{
+					Service:             service,
+					AbacTagKeys:         tagKeys,
+					AbacTagKeysSource:   tagKeysSource,
+					MissingTagKeyCounts: map[string]int{},
+				}
+				rows[service] = row
+			}
+			row.ResourceCount++
+			if len(tagKeys) == 0 {
+				continue
+			}
+
+			// Condition keys are case insensitive, so match tag keys that way
+			resourceTagKeys := map[string]bool{}
+			for _, tag := range resource.Tags {
+				resourceTagKeys[strings.ToLower(aws.ToString(tag.Key))] = true
+			}
+			missing := 0
+			for _, key := range tagKeys {
+				if !resourceTagKeys[strings.ToLower(key)] {
+					row.MissingTagKeyCounts[key]++
+					missing++
+				}
+			}
+			switch {
+			case missing == 0:
+				row.FullyTaggedResourceCount++
+			case missing < len(tagKeys):
+				row.PartiallyTaggedResourceCount++
+			}
+		}
+	}
+
+	services := make([]string, 0, len(rows))
+	for service := range rows {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	for _, service := range services {
+		row := rows[service]
+		if len(tagKeys) > 0 {
+			row.TagCoveragePercent = aws.Float64(float64(row.FullyTaggedResourceCount) * 100 / float64(row.ResourceCount))
+		}
+		if usage, ok := policyUsage.Services[service]; ok {
+			row.PolicyStatementCount = usage.StatementCount
+			row.ResourceTagStatementCount = usage.ResourceTagStatementCount
+			row.PrincipalTagStatementCount = usage.PrincipalTagStatementCount
+			row.AbacStatementCount = usage.AbacStatementCount
+		}
+		if row.PolicyStatementCount > 0 {
+			row.AbacStatementPercent = aws.Float64(float64(row.AbacStatementCount) * 100 / float64(row.PolicyStatementCount))
+		}
+
+		d.StreamListItem(ctx, row)
+
+		// Context may get cancelled due to manual cancellation or if the limit has been reached
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+// Policies are global, so the analysis is cached per connection and shared by
+// all regions
+var getAbacPolicyUsageMemoized = plugin.HydrateFunc(getAbacPolicyUsageUncached).Memoize()
+
+func getAbacPolicyUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getAbacPolicyUsageMemoized(ctx, d, h)
+}
+
+// getAbacPolicyUsageUncached counts the statements of customer managed and
+// inline policies per service, and which of them have tag conditions
+func getAbacPolicyUsageUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Get client
+	svc, err := IAMClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &iam.GetAccountAuthorizationDetailsInput{
+		Filter: []iamTypes.EntityType{
+			iamTypes.EntityTypeUser,
+			iamTypes.EntityTypeRole,
+			iamTypes.EntityTypeGroup,
+			iamTypes.EntityTypeLocalManagedPolicy,
+		},
+		MaxItems: aws.Int32(1000),
+	}
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(svc, input, func(o *iam.GetAccountAuthorizationDetailsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var documents []*string
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range output.UserDetailList {
+			for _, policy := range user.UserPolicyList {
+				documents = append(documents, policy.PolicyDocument)
+			}
+		}
+		for _, role := range output.RoleDetailList {
+			for _, policy := range role.RolePolicyList {
+				documents = append(documents, policy.PolicyDocument)
+			}
+		}
+		for _, group := range output.GroupDetailList {
+			for _, policy := range group.GroupPolicyList {
+				documents = append(documents, policy.PolicyDocument)
+			}
+		}
+		for _, policy := range output.Policies {
+			for _, version := range policy.PolicyVersionList {
+				if version.IsDefaultVersion {
+					documents = append(documents, version.Document)
+				}
+			}
+		}
+	}
+
+	usage := &abacPolicyUsage{Services: map[string]*abacServicePolicyUsage{}}
+	tagKeys := map[string]bool{}
+
+	for _, document := range documents {
+		if document == nil {
+			continue
+		}
+		decoded, err := url.QueryUnescape(*document)
+		if err != nil {
+			return nil, err
+		}
+		policy, err := canonicalPolicy(decoded)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, statement := range policy.(Policy).Statements {
+			resourceTag, principalTag := false, false
+			for _, condition := range statement.Condition {
+				for key := range condition.(map[string]interface{}) {
+					switch {
+					case strings.Contains(key, ":resourcetag/"):
+						resourceTag = true
+					case strings.HasPrefix(key, "aws:principaltag/"):
+						principalTag = true
+					case !strings.HasPrefix(key, "aws:requesttag/"):
+						continue
+					}
+					tagKeys[key[strings.Index(key, "/")+1:]] = true
+				}
+			}
+
+			// Statements with NotAction or all actions are not specific to a
+			// service, so are not counted
+			for _, service := range statementServices(statement) {
+				serviceUsage, ok := usage.Services[service]
+				if !ok {
+					serviceUsage = &abacServicePolicyUsage{}
+					usage.Services[service] = serviceUsage
+				}
+				serviceUsage.StatementCount++
+				if resourceTag {
+					serviceUsage.ResourceTagStatementCount++
+				}
+				if principalTag {
+					serviceUsage.PrincipalTagStatementCount++
+				}
+				if resourceTag || principalTag {
+					serviceUsage.AbacStatementCount++
+				}
+			}
+		}
+	}
+
+	for key := range tagKeys {
+		usage.TagKeys = append(usage.TagKeys, key)
+	}
+	sort.Strings(usage.TagKeys)
+
+	return usage, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// statementServices returns the service namespaces of the actions of the
+// statement, e.g. ec2 for ec2:StartInstances
+func statementServices(statement Statement) []string {
+	services := map[string]bool{}
+	for _, action := range statement.Action {
+		if i := strings.Index(action, ":"); i > 0 {
+			services[action[:i]] = true
+		}
+	}
+
+	result := make([]string, 0, len(services))
+	for service := range services {
+		result = append(result, service)
+	}
+	return result
+}
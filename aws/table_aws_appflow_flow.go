@@ -0,0 +1,334 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/appflow"
+	"github.com/aws/aws-sdk-go-v2/service/appflow/types"
+
+	appflowv1 "github.com/aws/aws-sdk-go/service/appflow"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsAppFlowFlow(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_appflow_flow",
+		Description: "AWS AppFlow Flow",
+		List: &plugin.ListConfig{
+			Hydrate: listAppFlowFlows,
+			Tags:    map[string]string{"service": "appflow", "action": "ListFlows"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getAppFlowFlow,
+				Tags: map[string]string{"service": "appflow", "action": "DescribeFlow"},
+			},
+			{
+				Func: getAppFlowFlowLastExecutionRecord,
+				Tags: map[string]string{"service": "appflow", "action": "DescribeFlowExecutionRecords"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(appflowv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the flow.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FlowName"),
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the flow.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FlowArn"),
+			},
+			{
+				Name:        "flow_status",
+				Description: "The current status of the flow. Possible values are: Active, Deprecated, Deleted, Draft, Errored or Suspended.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "flow_status_message",
+				Description: "Contains an error message if the flow status is in a suspended or error state.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAppFlowFlow,
+			},
+			{
+				Name:        "description",
+				Description: "A user-entered description of the flow.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_connector_type",
+				Description: "The type of the connector that the flow transfers data from, e.g. Salesforce or S3.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_connector_label",
+				Description: "The label of the custom source connector, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_connector_type",
+				Description: "The type of the connector that the flow transfers data to, e.g. Redshift or S3.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "destination_connector_label",
+				Description: "The label of the custom destination connector, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "trigger_type",
+				Description: "The type of flow trigger. Possible values are: Scheduled, Event or OnDemand.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "kms_arn",
+				Description: "The ARN of the KMS key used to encrypt the data transferred by the flow. Null if the AWS managed key is used.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAppFlowFlow,
+			},
+			{
+				Name:        "created_at",
+				Description: "The time at which the flow was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "created_by",
+				Description: "The ARN of the user who created the flow.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_updated_at",
+				Description: "The time at which the flow was last updated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "last_updated_by",
+				Description: "The ARN of the user who last updated the flow.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_execution_status",
+				Description: "The status of the most recent flow run.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAppFlowFlowLastExecutionRecord,
+				Transform:   transform.FromField("ExecutionStatus"),
+			},
+			{
+				Name:        "last_execution_started_at",
+				Description: "The time at which the most recent flow run started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getAppFlowFlowLastExecutionRecord,
+				Transform:   transform.FromField("StartedAt"),
+			},
+			{
+				Name:        "last_execution_bytes_processed",
+				Description: "The total number of bytes processed by the most recent flow run.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getAppFlowFlowLastExecutionRecord,
+				Transform:   transform.FromField("ExecutionResult.BytesProcessed"),
+			},
+			{
+				Name:        "last_execution_bytes_written",
+				Description: "The total number of bytes written to the destination by the most recent flow run.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getAppFlowFlowLastExecutionRecord,
+				Transform:   transform.FromField("ExecutionResult.BytesWritten"),
+			},
+			{
+				Name:        "last_execution_records_processed",
+				Description: "The number of records processed by the most recent flow run.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getAppFlowFlowLastExecutionRecord,
+				Transform:   transform.FromField("ExecutionResult.RecordsProcessed"),
+			},
+			{
+				Name:        "last_run_execution_details",
+				Description: "Describes the details of the most recent flow run.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "source_flow_config",
+				Description: "The configuration of the source connector, including the connector profile used to connect to it.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAppFlowFlow,
+			},
+			{
+				Name:        "destination_flow_config_list",
+				Description: "The configuration of the destination connectors, including the connector profiles used to connect to them.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAppFlowFlow,
+			},
+			{
+				Name:        "trigger_config",
+				Description: "The trigger settings that determine how and when the flow runs.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAppFlowFlow,
+			},
+			{
+				Name:        "tasks",
+				Description: "A list of tasks that Amazon AppFlow performs while transferring the data in the flow run.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAppFlowFlow,
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FlowName"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("FlowArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAppFlowFlows(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := AppFlowClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appflow_flow.listAppFlowFlows", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 1 {
+				maxLimit = 1
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &appflow.ListFlowsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := appflow.NewListFlowsPaginator(svc, input, func(o *appflow.ListFlowsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_appflow_flow.listAppFlowFlows", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.Flows {
+			d.StreamListItem(ctx, item)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getAppFlowFlow(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	flowName := h.Item.(types.FlowDefinition).FlowName
+
+	// Create session
+	svc, err := AppFlowClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appflow_flow.getAppFlowFlow", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &appflow.DescribeFlowInput{
+		FlowName: flowName,
+	}
+
+	op, err := svc.DescribeFlow(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appflow_flow.getAppFlowFlow", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+// getAppFlowFlowLastExecutionRecord returns the most recent run of the flow,
+// which has the volume of data it transferred
+func getAppFlowFlowLastExecutionRecord(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	flowName := h.Item.(types.FlowDefinition).FlowName
+
+	// Create session
+	svc, err := AppFlowClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appflow_flow.getAppFlowFlowLastExecutionRecord", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &appflow.DescribeFlowExecutionRecordsInput{
+		FlowName:   flowName,
+		MaxResults: aws.Int32(100),
+	}
+
+	op, err := svc.DescribeFlowExecutionRecords(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appflow_flow.getAppFlowFlowLastExecutionRecord", "api_error", err)
+		return nil, err
+	}
+
+	var last *types.ExecutionRecord
+	for i, record := range op.FlowExecutions {
+		if record.StartedAt == nil {
+			continue
+		}
+		if last == nil || record.StartedAt.After(*last.StartedAt) {
+			last = &op.FlowExecutions[i]
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	return last, nil
+}
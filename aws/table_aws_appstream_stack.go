@@ -0,0 +1,245 @@
+package aws
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/service/appstream"
+	"github.com/aws/aws-sdk-go-v2/service/appstream/types"
+	appstreamv1 "github.com/aws/aws-sdk-go/service/appstream"
+	"github.com/aws/smithy-go"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsAppStreamStack(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_appstream_stack",
+		Description: "AWS AppStream Stack",
+		List: &plugin.ListConfig{
+			Hydrate: listAppStreamStacks,
+			Tags:    map[string]string{"service": "appstream", "action": "DescribeStacks"},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+			KeyColumns: []*plugin.KeyColumn{
+				{
+					Name:    "name",
+					Require: plugin.Optional,
+				},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getAppStreamStackTags,
+				Tags: map[string]string{"service": "appstream", "action": "ListTagsForResource"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(appstreamv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the stack.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the stack.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "created_time",
+				Description: "The time the stack was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "description",
+				Description: "The description to display.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "display_name",
+				Description: "The stack name to display.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "streaming_restricted_to_vpc_endpoint",
+				Description: "True if streaming is only allowed through an interface VPC endpoint. Otherwise anyone holding a streaming URL for the stack can stream over the internet until the URL expires.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(appStreamStackStreamingRestrictedToVpcEndpoint),
+			},
+			{
+				Name:        "access_endpoints",
+				Description: "The interface VPC endpoints that users of the stack can connect to AppStream 2.0 through.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "embed_host_domains",
+				Description: "The domains where AppStream 2.0 streaming sessions can be embedded in an iframe.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "redirect_url",
+				Description: "The URL that users are redirected to after their streaming session ends.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RedirectURL"),
+			},
+			{
+				Name:        "feedback_url",
+				Description: "The URL that users are redirected to after they click the Send Feedback link.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("FeedbackURL"),
+			},
+			{
+				Name:        "user_settings",
+				Description: "The actions that are enabled or disabled for users during their streaming sessions, such as clipboard, file transfer and printing.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "storage_connectors",
+				Description: "The storage connectors that are enabled for the stack.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "application_settings",
+				Description: "The persistent application settings for users of the stack.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "streaming_experience_settings",
+				Description: "The streaming protocol preferred for the stack.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "stack_errors",
+				Description: "The errors for the stack.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAppStreamStackTags,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAppStreamStacks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create Session
+	svc, err := AppStreamClient(ctx, d)
+	if err != nil {
+		logger.Error("aws_appstream_stack.listAppStreamStacks", "connection_error", err)
+		return nil, err
+	}
+
+	// Unsupported region check
+	if svc == nil {
+		return nil, nil
+	}
+
+	params := &appstream.DescribeStacksInput{}
+
+	if d.Quals["name"] != nil {
+		for _, q := range d.Quals["name"].Quals {
+			value := q.Value.GetStringValue()
+			if q.Operator == "=" {
+				params.Names = append(params.Names, value)
+			}
+		}
+	}
+
+	for {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		op, err := svc.DescribeStacks(ctx, params)
+		if err != nil {
+			logger.Error("aws_appstream_stack.listAppStreamStacks", "api_error", err)
+			return nil, err
+		}
+
+		for _, stack := range op.Stacks {
+			d.StreamListItem(ctx, stack)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if op.NextToken == nil {
+			break
+		}
+		params.NextToken = op.NextToken
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getAppStreamStackTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	arn := h.Item.(types.Stack).Arn
+
+	// Create Session
+	svc, err := AppStreamClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_appstream_stack.getAppStreamStackTags", "connection_error", err)
+		return nil, err
+	}
+
+	params := &appstream.ListTagsForResourceInput{
+		ResourceArn: arn,
+	}
+
+	tags, err := svc.ListTagsForResource(ctx, params)
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) {
+			if ae.ErrorCode() == "ResourceNotFoundException" {
+				return nil, nil
+			}
+		}
+		plugin.Logger(ctx).Error("aws_appstream_stack.getAppStreamStackTags", "api_error", err)
+		return nil, err
+	}
+
+	return tags.Tags, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func appStreamStackStreamingRestrictedToVpcEndpoint(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	stack := d.HydrateItem.(types.Stack)
+
+	for _, endpoint := range stack.AccessEndpoints {
+		if endpoint.EndpointType == types.AccessEndpointTypeStreaming {
+			return true, nil
+		}
+	}
+	return false, nil
+}
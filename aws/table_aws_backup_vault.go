@@ -3,7 +3,9 @@ package aws
 import (
 	"context"
 	"errors"
+	"math"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/backup"
@@ -85,6 +87,38 @@ func tableAwsBackupVault(_ context.Context) *plugin.Table {
 				Description: "The number of recovery points that are stored in a backup vault.",
 				Type:        proto.ColumnType_DOUBLE,
 			},
+			{
+				Name:        "locked",
+				Description: "Indicates whether Backup Vault Lock is applied to the backup vault. If true, Vault Lock prevents delete and update operations on the recovery points in the vault.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "lock_mode",
+				Description: "The mode of the Backup Vault Lock applied to the backup vault. Possible values are: governance (the lock can be removed by users with sufficient IAM permissions) and compliance (the lock becomes immutable once the lock date is reached). Null if no lock is configured.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(backupVaultLockMode),
+			},
+			{
+				Name:        "lock_date",
+				Description: "The date and time when a compliance mode Backup Vault Lock becomes immutable and can no longer be changed or deleted.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "changeable_for_days",
+				Description: "The number of days remaining before a compliance mode Backup Vault Lock becomes immutable. 0 once the lock date has passed, null for vaults without a compliance mode lock.",
+				Type:        proto.ColumnType_INT,
+				Transform:   transform.From(backupVaultLockChangeableForDays),
+			},
+			{
+				Name:        "min_retention_days",
+				Description: "The minimum retention period, in days, that the vault retains its recovery points. Null if no lock is configured.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "max_retention_days",
+				Description: "The maximum retention period, in days, that the vault retains its recovery points. Null if no maximum is set.",
+				Type:        proto.ColumnType_INT,
+			},
 			{
 				Name:        "sns_topic_arn",
 				Description: "An ARN that uniquely identifies an Amazon Simple Notification Service.",
@@ -338,6 +372,46 @@ func getAwsBackupVaultAccessPolicy(ctx context.Context, d *plugin.QueryData, h *
 	return op, nil
 }
 
+//// TRANSFORM FUNCTIONS
+
+func backupVaultLockMode(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	locked, lockDate, minRetentionDays, maxRetentionDays := vaultLockConfiguration(d.HydrateItem)
+
+	// A vault lock always sets at least one of the retention periods
+	if !locked && minRetentionDays == nil && maxRetentionDays == nil {
+		return nil, nil
+	}
+	if lockDate != nil {
+		return "compliance", nil
+	}
+	return "governance", nil
+}
+
+func backupVaultLockChangeableForDays(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	_, lockDate, _, _ := vaultLockConfiguration(d.HydrateItem)
+	if lockDate == nil {
+		return nil, nil
+	}
+
+	remaining := time.Until(*lockDate)
+	if remaining <= 0 {
+		return 0, nil
+	}
+	return int64(math.Ceil(remaining.Hours() / 24)), nil
+}
+
+//// UTILITY FUNCTIONS
+
+func vaultLockConfiguration(item interface{}) (bool, *time.Time, *int64, *int64) {
+	switch item := item.(type) {
+	case types.BackupVaultListMember:
+		return aws.ToBool(item.Locked), item.LockDate, item.MinRetentionDays, item.MaxRetentionDays
+	case *backup.DescribeBackupVaultOutput:
+		return aws.ToBool(item.Locked), item.LockDate, item.MinRetentionDays, item.MaxRetentionDays
+	}
+	return false, nil, nil, nil
+}
+
 func vaultID(item interface{}) string {
 	switch item := item.(type) {
 	case types.BackupVaultListMember:
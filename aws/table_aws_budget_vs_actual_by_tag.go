@@ -0,0 +1,357 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/budgets"
+	budgetTypes "github.com/aws/aws-sdk-go-v2/service/budgets/types"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer"
+	"github.com/aws/aws-sdk-go-v2/service/costexplorer/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// budgetVsActualByTag is the month to date spend and the monthly budgets for
+// a single value of a cost allocation tag.
+type budgetVsActualByTag struct {
+	TagKey                 string
+	TagValue               *string
+	IsUntagged             bool
+	PeriodStart            time.Time
+	PeriodEnd              time.Time
+	ActualAmount           float64
+	ActualUnit             *string
+	PercentOfTotalSpend    *float64
+	BudgetNames            []string
+	BudgetedAmount         *float64
+	ForecastedAmount       *float64
+	RemainingAmount        *float64
+	PercentOfBudgetUsed    *float64
+	budgetedAmountSum      float64
+	forecastedAmountSum    float64
+	hasForecastedAmountSum bool
+}
+
+//// TABLE DEFINITION
+
+func tableAwsBudgetVsActualByTag(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_budget_vs_actual_by_tag",
+		Description: "AWS Budget vs Actual Spend By Tag",
+		List: &plugin.ListConfig{
+			Hydrate: listBudgetVsActualByTag,
+			Tags:    map[string]string{"service": "ce", "action": "GetCostAndUsage"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tag_key", Require: plugin.Required},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "tag_key",
+				Description: "The cost allocation tag key that spend and budgets are grouped by, e.g. CostCenter.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tag_value",
+				Description: "The value of the tag. Null for the spend of resources that do not have the tag.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_untagged",
+				Description: "True if the row is the spend of resources that do not have the tag.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "period_start",
+				Description: "The start of the current month, which spend and budgets are reported for.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "period_end",
+				Description: "The end of the current month, which spend and budgets are reported for.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "actual_amount",
+				Description: "The unblended cost for the tag value in the month to date, as reported by Cost Explorer.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "actual_unit",
+				Description: "The unit of the actual amount, e.g. USD.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "percent_of_total_spend",
+				Description: "The actual amount for the tag value as a percentage of the spend across all values, including untagged spend.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "budget_names",
+				Description: "The names of the monthly cost budgets filtered to this tag value only, with no other cost filters.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "budgeted_amount",
+				Description: "The sum of the budgeted amounts for the current month of the budgets in budget_names. Null if the tag value has no budget.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "forecasted_amount",
+				Description: "The sum of the spend forecasted by AWS Budgets for the current month of the budgets in budget_names.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "remaining_amount",
+				Description: "The budgeted amount less the actual amount. Negative if the tag value is over budget.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "percent_of_budget_used",
+				Description: "The actual amount as a percentage of the budgeted amount.",
+				Type:        proto.ColumnType_DOUBLE,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(budgetVsActualByTagTitle),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listBudgetVsActualByTag(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	tagKey := d.EqualsQualString("tag_key")
+
+	now := time.Now().UTC()
+	periodStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	periodEnd := periodStart.AddDate(0, 1, 0)
+
+	rows := map[string]*budgetVsActualByTag{}
+	getRow := func(tagValue string) *budgetVsActualByTag {
+		if row, ok := rows[tagValue]; ok {
+			return row
+		}
+		row := &budgetVsActualByTag{
+			TagKey:      tagKey,
+			IsUntagged:  tagValue == "",
+			PeriodStart: periodStart,
+			PeriodEnd:   periodEnd,
+			BudgetNames: []string{},
+		}
+		if tagValue != "" {
+			row.TagValue = aws.String(tagValue)
+		}
+		rows[tagValue] = row
+		return row
+	}
+
+	// Actual spend, grouped by the tag. Cost Explorer returns the spend of
+	// resources without the tag under an empty tag value.
+	ceSvc, err := CostExplorerClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_budget_vs_actual_by_tag.listBudgetVsActualByTag", "client_error", err)
+		return nil, err
+	}
+
+	params := &costexplorer.GetCostAndUsageInput{
+		TimePeriod: &types.DateInterval{
+			Start: aws.String(periodStart.Format("2006-01-02")),
+			End:   aws.String(now.AddDate(0, 0, 1).Format("2006-01-02")),
+		},
+		Granularity: types.GranularityMonthly,
+		Metrics:     []string{"UnblendedCost"},
+		GroupBy: []types.GroupDefinition{
+			{
+				Type: types.GroupDefinitionTypeTag,
+				Key:  aws.String(tagKey),
+			},
+		},
+	}
+
+	var totalSpend float64
+	for {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := ceSvc.GetCostAndUsage(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_budget_vs_actual_by_tag.listBudgetVsActualByTag", "api_error", err)
+			return nil, err
+		}
+
+		for _, result := range output.ResultsByTime {
+			for _, group := range result.Groups {
+				if len(group.Keys) == 0 {
+					continue
+				}
+				// Keys are in the form "<tag key>$<tag value>"
+				_, tagValue, _ := strings.Cut(group.Keys[0], "$")
+				metric := group.Metrics["UnblendedCost"]
+				amount, err := strconv.ParseFloat(aws.ToString(metric.Amount), 64)
+				if err != nil {
+					continue
+				}
+				row := getRow(tagValue)
+				row.ActualAmount += amount
+				row.ActualUnit = metric.Unit
+				totalSpend += amount
+			}
+		}
+
+		if output.NextPageToken == nil {
+			break
+		}
+		params.NextPageToken = output.NextPageToken
+	}
+
+	// Monthly cost budgets filtered to a single value of the tag
+	budgetList, err := listBudgetsForBudgetVsActual(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, budget := range budgetList {
+		if budget.BudgetType != budgetTypes.BudgetTypeCost || budget.TimeUnit != budgetTypes.TimeUnitMonthly {
+			continue
+		}
+		tagValue, ok := budgetTagValueFilter(budget, tagKey)
+		if !ok {
+			continue
+		}
+
+		row := getRow(tagValue)
+		row.BudgetNames = append(row.BudgetNames, aws.ToString(budget.BudgetName))
+
+		// Planned budgets have a different limit for each period, keyed by the
+		// start of the period in epoch seconds
+		limit := budget.BudgetLimit
+		if planned, ok := budget.PlannedBudgetLimits[strconv.FormatInt(periodStart.Unix(), 10)]; ok {
+			limit = &planned
+		}
+		if limit != nil {
+			if amount, err := strconv.ParseFloat(aws.ToString(limit.Amount), 64); err == nil {
+				row.budgetedAmountSum += amount
+			}
+		}
+		if budget.CalculatedSpend != nil && budget.CalculatedSpend.ForecastedSpend != nil {
+			if amount, err := strconv.ParseFloat(aws.ToString(budget.CalculatedSpend.ForecastedSpend.Amount), 64); err == nil {
+				row.forecastedAmountSum += amount
+				row.hasForecastedAmountSum = true
+			}
+		}
+	}
+
+	tagValues := make([]string, 0, len(rows))
+	for tagValue := range rows {
+		tagValues = append(tagValues, tagValue)
+	}
+	sort.Strings(tagValues)
+
+	for _, tagValue := range tagValues {
+		row := rows[tagValue]
+		if totalSpend != 0 {
+			row.PercentOfTotalSpend = aws.Float64(row.ActualAmount / totalSpend * 100)
+		}
+		if len(row.BudgetNames) > 0 {
+			row.BudgetedAmount = aws.Float64(row.budgetedAmountSum)
+			row.RemainingAmount = aws.Float64(row.budgetedAmountSum - row.ActualAmount)
+			if row.budgetedAmountSum != 0 {
+				row.PercentOfBudgetUsed = aws.Float64(row.ActualAmount / row.budgetedAmountSum * 100)
+			}
+			if row.hasForecastedAmountSum {
+				row.ForecastedAmount = aws.Float64(row.forecastedAmountSum)
+			}
+		}
+
+		d.StreamListItem(ctx, *row)
+
+		// Context may get cancelled due to manual cancellation or if the limit has been reached
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func listBudgetsForBudgetVsActual(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) ([]budgetTypes.Budget, error) {
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_budget_vs_actual_by_tag.listBudgetsForBudgetVsActual", "common_data_error", err)
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	svc, err := BudgetsClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_budget_vs_actual_by_tag.listBudgetsForBudgetVsActual", "client_error", err)
+		return nil, err
+	}
+
+	input := &budgets.DescribeBudgetsInput{
+		AccountId:  aws.String(commonColumnData.AccountId),
+		MaxResults: aws.Int32(100),
+	}
+
+	paginator := budgets.NewDescribeBudgetsPaginator(svc, input, func(o *budgets.DescribeBudgetsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var budgetList []budgetTypes.Budget
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_budget_vs_actual_by_tag.listBudgetsForBudgetVsActual", "api_error", err)
+			return nil, err
+		}
+		budgetList = append(budgetList, output.Budgets...)
+	}
+
+	return budgetList, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func budgetVsActualByTagTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	row := d.HydrateItem.(budgetVsActualByTag)
+	if row.IsUntagged {
+		return fmt.Sprintf("%s (untagged)", row.TagKey), nil
+	}
+	return fmt.Sprintf("%s=%s", row.TagKey, aws.ToString(row.TagValue)), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// budgetTagValueFilter returns the value of tagKey that the budget is
+// filtered to. Budgets filtered to several values of the tag cannot be
+// attributed to one of them, and budgets with other filters, e.g. on a
+// service or another tag, only track part of the tag value's spend, so both
+// return ok == false.
+func budgetTagValueFilter(budget budgetTypes.Budget, tagKey string) (string, bool) {
+	prefix := "user:" + tagKey + "$"
+
+	if len(budget.CostFilters) != 1 {
+		return "", false
+	}
+	filters := budget.CostFilters["TagKeyValue"]
+	if len(filters) != 1 || !strings.HasPrefix(filters[0], prefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(filters[0], prefix), true
+}
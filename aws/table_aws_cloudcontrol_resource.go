@@ -24,6 +24,12 @@ func tableAwsCloudControlResource(_ context.Context) *plugin.Table {
 				{Name: "type_name", Require: plugin.Required},
 				{Name: "resource_model", Require: plugin.Optional},
 			},
+			// Niche resource types, e.g. AWS::GroundStation::Config or
+			// AWS::RoboMaker::Fleet, are only registered in the regions the
+			// service is available in
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"TypeNotFoundException", "UnsupportedActionException"}),
+			},
 			Hydrate: listCloudControlResources,
 			Tags:    map[string]string{"service": "cloudformation", "action": "ListResources"},
 		},
@@ -32,6 +38,9 @@ func tableAwsCloudControlResource(_ context.Context) *plugin.Table {
 				{Name: "type_name"},
 				{Name: "identifier"},
 			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "TypeNotFoundException", "UnsupportedActionException"}),
+			},
 			Hydrate: getCloudControlResource,
 			Tags:    map[string]string{"service": "cloudformation", "action": "GetResource"},
 		},
@@ -0,0 +1,256 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+	"github.com/aws/aws-sdk-go-v2/service/configservice/types"
+
+	configservicev1 "github.com/aws/aws-sdk-go/service/configservice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsConfigResourceHistory(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_config_resource_history",
+		Description: "AWS Config Resource History",
+		List: &plugin.ListConfig{
+			Hydrate: listConfigResourceHistory,
+			Tags:    map[string]string{"service": "config", "action": "GetResourceConfigHistory"},
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "resource_type", Require: plugin.Required},
+				{Name: "resource_id", Require: plugin.Required},
+				{Name: "configuration_item_capture_time", Require: plugin.Optional, Operators: []string{">", ">=", "<", "<=", "="}},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				// ResourceNotDiscoveredException - The resource has not been recorded by AWS Config in the region.
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotDiscoveredException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(configservicev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource (for example, sg-xxxxxx).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of Amazon Web Services resource (for example, AWS::EC2::SecurityGroup).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_name",
+				Description: "The custom name of the resource, if available.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) associated with the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "configuration_item_capture_time",
+				Description: "The time when the configuration recording was initiated.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "configuration_item_delivery_time",
+				Description: "The time when configuration changes for the resource were delivered.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "configuration_item_status",
+				Description: "The configuration item status. Possible values are OK, ResourceDiscovered, ResourceNotRecorded, ResourceDeleted and ResourceDeletedNotRecorded.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "configuration_state_id",
+				Description: "An identifier that indicates the ordering of the configuration items of a resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "configuration_item_md5_hash",
+				Description: "Unique MD5 hash that represents the configuration item's state.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ConfigurationItemMD5Hash"),
+			},
+			{
+				Name:        "availability_zone",
+				Description: "The Availability Zone associated with the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "recording_frequency",
+				Description: "The recording frequency that Config uses to record configuration changes for the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_creation_time",
+				Description: "The time stamp when the resource was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "version",
+				Description: "The version number of the resource configuration.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "configuration",
+				Description: "The description of the resource configuration.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Configuration").Transform(transform.UnmarshalYAML),
+			},
+			{
+				Name:        "supplementary_configuration",
+				Description: "Configuration attributes that Config returns for certain resource types to supplement the information returned for the configuration parameter.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "related_events",
+				Description: "A list of CloudTrail event IDs that are related to the configuration change.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "relationships",
+				Description: "A list of related Amazon Web Services resources.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listConfigResourceHistory(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := ConfigClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_config_resource_history.listConfigResourceHistory", "get_client_error", err)
+		return nil, err
+	}
+
+	// Configuration items are returned in reverse chronological order, so
+	// "where configuration_item_capture_time <= '2024-01-01' limit 1" returns
+	// the configuration of the resource on that date.
+	input, bounds := buildConfigResourceHistoryInput(d)
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+	input.Limit = maxLimit
+
+	paginator := configservice.NewGetResourceConfigHistoryPaginator(svc, input, func(o *configservice.GetResourceConfigHistoryPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_config_resource_history.listConfigResourceHistory", "api_error", err)
+			return nil, err
+		}
+
+		for _, item := range output.ConfigurationItems {
+			// Items at an exclusive bound would be filtered out by Postgres,
+			// but still count against the limit
+			if bounds.excludes(item.ConfigurationItemCaptureTime) {
+				continue
+			}
+
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTION
+
+// configResourceHistoryBounds are the capture times of exclusive quals (">",
+// "<"). GetResourceConfigHistory treats EarlierTime and LaterTime as
+// inclusive, so returns the items captured at these times too.
+type configResourceHistoryBounds struct {
+	after  *time.Time
+	before *time.Time
+}
+
+// excludes returns true if an item captured at captureTime is at an exclusive
+// bound
+func (b configResourceHistoryBounds) excludes(captureTime *time.Time) bool {
+	if captureTime == nil {
+		return false
+	}
+	return (b.after != nil && captureTime.Equal(*b.after)) || (b.before != nil && captureTime.Equal(*b.before))
+}
+
+// Build the GetResourceConfigHistory input from the resource and capture time
+// quals, and the exclusive bounds whose items must be skipped
+func buildConfigResourceHistoryInput(d *plugin.QueryData) (*configservice.GetResourceConfigHistoryInput, configResourceHistoryBounds) {
+	input := &configservice.GetResourceConfigHistoryInput{
+		ResourceId:   aws.String(d.EqualsQualString("resource_id")),
+		ResourceType: types.ResourceType(d.EqualsQualString("resource_type")),
+	}
+	var bounds configResourceHistoryBounds
+
+	quals := d.Quals
+	if quals["configuration_item_capture_time"] != nil {
+		for _, q := range quals["configuration_item_capture_time"].Quals {
+			captureTime := q.Value.GetTimestampValue().AsTime()
+			switch q.Operator {
+			case ">=", ">":
+				input.EarlierTime = aws.Time(captureTime)
+				bounds.after = nil
+				if q.Operator == ">" {
+					bounds.after = aws.Time(captureTime)
+				}
+			case "<", "<=":
+				input.LaterTime = aws.Time(captureTime)
+				bounds.before = nil
+				if q.Operator == "<" {
+					bounds.before = aws.Time(captureTime)
+				}
+			case "=":
+				input.EarlierTime = aws.Time(captureTime)
+				input.LaterTime = aws.Time(captureTime)
+			}
+		}
+	}
+
+	return input, bounds
+}
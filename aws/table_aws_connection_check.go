@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Results of a connection check, as shown in the status column
+const (
+	connectionCheckStatusOk      = "ok"
+	connectionCheckStatusWarning = "warning"
+	connectionCheckStatusError   = "error"
+	connectionCheckStatusSkipped = "skipped"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsConnectionCheck(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_connection_check",
+		Description: "AWS Connection Check",
+		List: &plugin.ListConfig{
+			Hydrate: listConnectionChecks,
+			Tags:    map[string]string{"service": "sts", "action": "GetCallerIdentity"},
+		},
+		// The standard account, region and partition columns are not used, as
+		// they need working credentials and this table must return rows
+		// explaining why the credentials do not work
+		Columns: []*plugin.Column{
+			{
+				Name:        "check",
+				Description: "The check performed. Possible values are: config, profile, credentials, region or permission.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "target",
+				Description: "What was checked, e.g. the region name for region checks or the API action for permission checks.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The result of the check. Possible values are: ok, warning, error or skipped.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "message",
+				Description: "Details of the result, including the action to take if the check failed.",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+type connectionCheck struct {
+	Check   string
+	Target  string
+	Status  string
+	Message string
+}
+
+//// LIST FUNCTION
+
+// listConnectionChecks runs the checks in order, stopping at the first check
+// that the later ones depend on. Errors are returned as rows, not as query
+// errors.
+func listConnectionChecks(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	awsSpcConfig := GetConfig(d.Connection)
+
+	// Config
+	problems := validateConfig(awsSpcConfig)
+	for _, problem := range problems {
+		d.StreamListItem(ctx, connectionCheck{Check: "config", Status: connectionCheckStatusError, Message: problem})
+	}
+	if len(problems) > 0 {
+		return nil, nil
+	}
+	for _, warning := range configWarnings(awsSpcConfig) {
+		d.StreamListItem(ctx, connectionCheck{Check: "config", Status: connectionCheckStatusWarning, Message: warning})
+	}
+	d.StreamListItem(ctx, connectionCheck{Check: "config", Status: connectionCheckStatusOk, Message: "Connection config is valid."})
+
+	// Profile, including any source_profile chain used to assume roles
+	if awsSpcConfig.Profile != nil {
+		profile := *awsSpcConfig.Profile
+		if _, err := config.LoadSharedConfigProfile(ctx, profile); err != nil {
+			d.StreamListItem(ctx, connectionCheck{Check: "profile", Target: profile, Status: connectionCheckStatusError, Message: fmt.Sprintf("Profile cannot be loaded from the shared config and credentials files: %s", err)})
+			return nil, nil
+		}
+		d.StreamListItem(ctx, connectionCheck{Check: "profile", Target: profile, Status: connectionCheckStatusOk, Message: "Profile and its source profiles were loaded."})
+	}
+
+	// Credentials
+	identity, err := getCallerIdentity(ctx, d, nil)
+	if err != nil {
+		d.StreamListItem(ctx, connectionCheck{Check: "credentials", Status: connectionCheckStatusError, Message: fmt.Sprintf("Credentials cannot be used to call sts:GetCallerIdentity: %s", err)})
+		return nil, nil
+	}
+	callerIdentity := identity.(*sts.GetCallerIdentityOutput)
+	d.StreamListItem(ctx, connectionCheck{
+		Check:   "credentials",
+		Target:  aws.ToString(callerIdentity.Arn),
+		Status:  connectionCheckStatusOk,
+		Message: fmt.Sprintf("Authenticated to account %s.", aws.ToString(callerIdentity.Account)),
+	})
+
+	// Regions
+	regions, err := listQueryRegionsForConnection(ctx, d)
+	if err != nil {
+		d.StreamListItem(ctx, connectionCheck{Check: "region", Status: connectionCheckStatusError, Message: fmt.Sprintf("Regions to query cannot be resolved: %s", err)})
+		return nil, nil
+	}
+	if len(regions) == 0 {
+		d.StreamListItem(ctx, connectionCheck{Check: "region", Status: connectionCheckStatusError, Message: "No enabled region matches \"regions\" in the connection config, so queries return no rows."})
+	}
+	for _, region := range regions {
+		check := connectionCheck{Check: "region", Target: region, Status: connectionCheckStatusOk, Message: "Region is queried."}
+		reason, err := getRegionAccessReasonCached(ctx, d, &plugin.HydrateData{Item: region})
+		if err != nil {
+			check.Status = connectionCheckStatusError
+			check.Message = fmt.Sprintf("Credentials cannot be used in the region: %s", err)
+		} else if reason.(string) != "" {
+			check.Status = connectionCheckStatusSkipped
+			check.Message = fmt.Sprintf("Region is skipped by queries (%s).", reason.(string))
+		}
+		d.StreamListItem(ctx, check)
+	}
+
+	// Permissions needed by most tables, or by the plugin itself
+	for _, check := range connectionPermissionChecks(ctx, d) {
+		d.StreamListItem(ctx, check)
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// connectionPermissionChecks calls read-only APIs the plugin relies on and
+// reports whether each one is allowed
+func connectionPermissionChecks(ctx context.Context, d *plugin.QueryData) []connectionCheck {
+	var checks []connectionCheck
+
+	result := func(action string, err error) connectionCheck {
+		if err != nil {
+			return connectionCheck{Check: "permission", Target: action, Status: connectionCheckStatusError, Message: err.Error()}
+		}
+		return connectionCheck{Check: "permission", Target: action, Status: connectionCheckStatusOk, Message: "Action is allowed."}
+	}
+
+	// Used to list the regions enabled for the account
+	ec2Svc, err := EC2Client(ctx, d)
+	if err == nil {
+		_, err = ec2Svc.DescribeRegions(ctx, &ec2.DescribeRegionsInput{})
+	}
+	checks = append(checks, result("ec2:DescribeRegions", err))
+
+	// Used by the IAM tables, and a good sign of a read-only policy being attached
+	iamSvc, err := IAMClient(ctx, d)
+	if err == nil {
+		_, err = iamSvc.GetAccountSummary(ctx, &iam.GetAccountSummaryInput{})
+	}
+	checks = append(checks, result("iam:GetAccountSummary", err))
+
+	return checks
+}
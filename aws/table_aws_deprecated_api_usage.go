@@ -0,0 +1,564 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/autoscaling"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancing"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Categories of deprecated feature usage
+const (
+	deprecatedApiUsageLaunchConfiguration = "launch_configuration"
+	deprecatedApiUsageClassicLoadBalancer = "classic_load_balancer"
+	deprecatedApiUsageEc2Classic          = "ec2_classic"
+	deprecatedApiUsageImdsv1              = "imdsv1"
+	deprecatedApiUsageTls10Policy         = "tls_1_0_policy"
+	deprecatedApiUsageLambdaRuntime       = "lambda_runtime"
+)
+
+// EC2-Classic was retired on August 15, 2022
+// https://aws.amazon.com/blogs/aws/ec2-classic-is-retiring-heres-how-to-prepare/
+var ec2ClassicRetirementDate = time.Date(2022, 8, 15, 0, 0, 0, 0, time.UTC)
+
+type lambdaRuntimeDeprecation struct {
+	MigrationTarget string
+	Deadline        time.Time
+}
+
+// Lambda runtimes past or scheduled for deprecation, after which functions
+// using them no longer get security patches, with the runtime to move to.
+//
+// AWS announces deprecations about 6 months ahead. To keep the list current,
+// add the runtimes in the "Deprecated runtimes" and "Supported runtimes" tables
+// of the page below that have a deprecation date, using that date as the
+// deadline, and move migration targets that become deprecated to the next
+// version. TestDeprecatedLambdaRuntimes checks that no target is deprecated.
+// https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html
+var deprecatedLambdaRuntimes = map[string]lambdaRuntimeDeprecation{
+	"python2.7":     {"python3.13", time.Date(2021, 7, 15, 0, 0, 0, 0, time.UTC)},
+	"python3.6":     {"python3.13", time.Date(2022, 7, 18, 0, 0, 0, 0, time.UTC)},
+	"python3.7":     {"python3.13", time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC)},
+	"python3.8":     {"python3.13", time.Date(2024, 10, 14, 0, 0, 0, 0, time.UTC)},
+	"python3.9":     {"python3.13", time.Date(2025, 12, 15, 0, 0, 0, 0, time.UTC)},
+	"nodejs":        {"nodejs22.x", time.Date(2016, 10, 31, 0, 0, 0, 0, time.UTC)},
+	"nodejs4.3":     {"nodejs22.x", time.Date(2020, 3, 5, 0, 0, 0, 0, time.UTC)},
+	"nodejs6.10":    {"nodejs22.x", time.Date(2019, 8, 12, 0, 0, 0, 0, time.UTC)},
+	"nodejs8.10":    {"nodejs22.x", time.Date(2020, 3, 6, 0, 0, 0, 0, time.UTC)},
+	"nodejs10.x":    {"nodejs22.x", time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC)},
+	"nodejs12.x":    {"nodejs22.x", time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC)},
+	"nodejs14.x":    {"nodejs22.x", time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC)},
+	"nodejs16.x":    {"nodejs22.x", time.Date(2024, 6, 12, 0, 0, 0, 0, time.UTC)},
+	"nodejs18.x":    {"nodejs22.x", time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)},
+	"nodejs20.x":    {"nodejs22.x", time.Date(2026, 4, 30, 0, 0, 0, 0, time.UTC)},
+	"ruby2.5":       {"ruby3.3", time.Date(2021, 7, 30, 0, 0, 0, 0, time.UTC)},
+	"ruby2.7":       {"ruby3.3", time.Date(2023, 12, 7, 0, 0, 0, 0, time.UTC)},
+	"ruby3.2":       {"ruby3.3", time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)},
+	"dotnetcore1.0": {"dotnet8", time.Date(2019, 7, 30, 0, 0, 0, 0, time.UTC)},
+	"dotnetcore2.0": {"dotnet8", time.Date(2019, 5, 30, 0, 0, 0, 0, time.UTC)},
+	"dotnetcore2.1": {"dotnet8", time.Date(2022, 1, 5, 0, 0, 0, 0, time.UTC)},
+	"dotnetcore3.1": {"dotnet8", time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)},
+	"dotnet5.0":     {"dotnet8", time.Date(2022, 5, 10, 0, 0, 0, 0, time.UTC)},
+	"dotnet6":       {"dotnet8", time.Date(2024, 12, 20, 0, 0, 0, 0, time.UTC)},
+	"dotnet7":       {"dotnet8", time.Date(2024, 5, 14, 0, 0, 0, 0, time.UTC)},
+	"go1.x":         {"provided.al2023", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+	"provided":      {"provided.al2023", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+	"java8":         {"java21", time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+	// Runtimes based on Amazon Linux 2 are deprecated when it reaches end of
+	// support
+	"provided.al2": {"provided.al2023", time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)},
+	"java8.al2":    {"java21", time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)},
+	"java11":       {"java21", time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)},
+	"java17":       {"java21", time.Date(2026, 6, 30, 0, 0, 0, 0, time.UTC)},
+}
+
+// deprecatedApiUsageCheck looks for one or more categories of deprecated
+// feature usage in the query region
+type deprecatedApiUsageCheck struct {
+	Categories []string
+	Find       func(context.Context, *plugin.QueryData, *plugin.HydrateData) ([]deprecatedApiUsage, error)
+}
+
+// Checks are run in this order. Each is skipped unless the category qual, if
+// set, is one of its categories.
+var deprecatedApiUsageChecks = []deprecatedApiUsageCheck{
+	{[]string{deprecatedApiUsageLaunchConfiguration}, findLaunchConfigurationUsage},
+	{[]string{deprecatedApiUsageClassicLoadBalancer, deprecatedApiUsageEc2Classic}, findClassicLoadBalancerUsage},
+	{[]string{deprecatedApiUsageEc2Classic}, findEc2ClassicPlatformUsage},
+	{[]string{deprecatedApiUsageImdsv1}, findImdsv1Usage},
+	{[]string{deprecatedApiUsageTls10Policy}, findTls10PolicyUsage},
+	{[]string{deprecatedApiUsageLambdaRuntime}, findDeprecatedLambdaRuntimeUsage},
+}
+
+type deprecatedApiUsage struct {
+	Category          string
+	ResourceType      string
+	ResourceId        string
+	ResourceArn       *string
+	DeprecatedFeature string
+	CurrentValue      string
+	MigrationTarget   string
+	Deadline          *time.Time
+	DeadlinePassed    *bool
+	Description       string
+	ReferenceUrl      string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsDeprecatedApiUsage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_deprecated_api_usage",
+		Description: "AWS Deprecated API Usage",
+		List: &plugin.ListConfig{
+			Hydrate: listDeprecatedApiUsage,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "category", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "category",
+				Description: "The kind of deprecated feature used. Possible values are: launch_configuration, classic_load_balancer, ec2_classic, imdsv1, tls_1_0_policy and lambda_runtime.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource using the deprecated feature, e.g. lambda_function.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The name or ID of the resource using the deprecated feature.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_arn",
+				Description: "The ARN of the resource using the deprecated feature, if it has one.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "deprecated_feature",
+				Description: "The deprecated feature the resource relies on.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "current_value",
+				Description: "The setting of the resource that relies on the deprecated feature, e.g. the Lambda runtime or SSL policy name.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "migration_target",
+				Description: "The feature or setting AWS recommends moving to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "deadline",
+				Description: "The date AWS announced the feature is retired or stops being supported. Null if AWS has not announced one.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "deadline_passed",
+				Description: "True if the deadline is in the past. Null if there is no deadline.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "description",
+				Description: "Why the feature is deprecated, and what changes when migrating.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reference_url",
+				Description: "The AWS documentation for the deprecation or migration.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ResourceId"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ResourceArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listDeprecatedApiUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	category := d.EqualsQualString("category")
+
+	for _, check := range deprecatedApiUsageChecks {
+		if category != "" && !deprecatedApiUsageCheckHasCategory(check, category) {
+			continue
+		}
+
+		findings, err := check.Find(ctx, d, h)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		for _, finding := range findings {
+			if category != "" && finding.Category != category {
+				continue
+			}
+			if finding.Deadline != nil {
+				finding.DeadlinePassed = aws.Bool(finding.Deadline.Before(now))
+			}
+			d.StreamListItem(ctx, finding)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func deprecatedApiUsageCheckHasCategory(check deprecatedApiUsageCheck, category string) bool {
+	for _, c := range check.Categories {
+		if c == category {
+			return true
+		}
+	}
+	return false
+}
+
+//// CHECKS
+
+// findLaunchConfigurationUsage lists the Auto Scaling launch configurations,
+// which are superseded by launch templates
+func findLaunchConfigurationUsage(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := AutoScalingClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findLaunchConfigurationUsage", "connection_error", err)
+		return nil, err
+	}
+
+	var findings []deprecatedApiUsage
+	paginator := autoscaling.NewDescribeLaunchConfigurationsPaginator(svc, &autoscaling.DescribeLaunchConfigurationsInput{}, func(o *autoscaling.DescribeLaunchConfigurationsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_deprecated_api_usage.findLaunchConfigurationUsage", "api_error", err)
+			return nil, err
+		}
+		for _, launchConfiguration := range output.LaunchConfigurations {
+			findings = append(findings, deprecatedApiUsage{
+				Category:          deprecatedApiUsageLaunchConfiguration,
+				ResourceType:      "launch_configuration",
+				ResourceId:        aws.ToString(launchConfiguration.LaunchConfigurationName),
+				ResourceArn:       launchConfiguration.LaunchConfigurationARN,
+				DeprecatedFeature: "Auto Scaling launch configuration",
+				CurrentValue:      aws.ToString(launchConfiguration.InstanceType),
+				MigrationTarget:   "EC2 launch template",
+				Description:       "Launch configurations do not support instance types released after December 31, 2022, or newer EC2 features, and accounts created after October 1, 2024 cannot create them. Copy the launch configuration to a launch template and update the Auto Scaling groups that use it.",
+				ReferenceUrl:      "https://docs.aws.amazon.com/autoscaling/ec2/userguide/migrate-to-launch-templates.html",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// findClassicLoadBalancerUsage lists the Classic Load Balancers. Those not in
+// a VPC are EC2-Classic leftovers.
+func findClassicLoadBalancerUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := ELBClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findClassicLoadBalancerUsage", "connection_error", err)
+		return nil, err
+	}
+
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	var findings []deprecatedApiUsage
+	paginator := elasticloadbalancing.NewDescribeLoadBalancersPaginator(svc, &elasticloadbalancing.DescribeLoadBalancersInput{}, func(o *elasticloadbalancing.DescribeLoadBalancersPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_deprecated_api_usage.findClassicLoadBalancerUsage", "api_error", err)
+			return nil, err
+		}
+		for _, loadBalancer := range output.LoadBalancerDescriptions {
+			name := aws.ToString(loadBalancer.LoadBalancerName)
+			arn := "arn:" + commonColumnData.Partition + ":elasticloadbalancing:" + region + ":" + commonColumnData.AccountId + ":loadbalancer/" + name
+
+			if loadBalancer.VPCId == nil {
+				findings = append(findings, deprecatedApiUsage{
+					Category:          deprecatedApiUsageEc2Classic,
+					ResourceType:      "classic_load_balancer",
+					ResourceId:        name,
+					ResourceArn:       aws.String(arn),
+					DeprecatedFeature: "EC2-Classic networking",
+					MigrationTarget:   "Load balancer in a VPC",
+					Deadline:          aws.Time(ec2ClassicRetirementDate),
+					Description:       "The load balancer is not in a VPC, so was created in EC2-Classic, which has been retired. Create a load balancer in a VPC and move traffic to it.",
+					ReferenceUrl:      "https://aws.amazon.com/blogs/aws/ec2-classic-is-retiring-heres-how-to-prepare/",
+				})
+				continue
+			}
+
+			findings = append(findings, deprecatedApiUsage{
+				Category:          deprecatedApiUsageClassicLoadBalancer,
+				ResourceType:      "classic_load_balancer",
+				ResourceId:        name,
+				ResourceArn:       aws.String(arn),
+				DeprecatedFeature: "Classic Load Balancer",
+				MigrationTarget:   "Application Load Balancer or Network Load Balancer",
+				Description:       "Classic Load Balancers are a previous generation load balancer, without support for newer features such as host and path based routing, TLS 1.3 security policies or AWS WAF. Use the migration wizard to create an equivalent Application or Network Load Balancer.",
+				ReferenceUrl:      "https://docs.aws.amazon.com/elasticloadbalancing/latest/userguide/migrate-classic-load-balancer.html",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+// findEc2ClassicPlatformUsage checks whether the account still supports the
+// EC2-Classic platform in the region
+func findEc2ClassicPlatformUsage(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findEc2ClassicPlatformUsage", "connection_error", err)
+		return nil, err
+	}
+
+	d.WaitForListRateLimit(ctx)
+	output, err := svc.DescribeAccountAttributes(ctx, &ec2.DescribeAccountAttributesInput{
+		AttributeNames: []ec2Types.AccountAttributeName{ec2Types.AccountAttributeNameSupportedPlatforms},
+	})
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findEc2ClassicPlatformUsage", "api_error", err)
+		return nil, err
+	}
+
+	for _, attribute := range output.AccountAttributes {
+		for _, value := range attribute.AttributeValues {
+			if aws.ToString(value.AttributeValue) != "EC2" {
+				continue
+			}
+			return []deprecatedApiUsage{{
+				Category:          deprecatedApiUsageEc2Classic,
+				ResourceType:      "account_attribute",
+				ResourceId:        "supported-platforms",
+				DeprecatedFeature: "EC2-Classic networking",
+				CurrentValue:      "EC2",
+				MigrationTarget:   "VPC",
+				Deadline:          aws.Time(ec2ClassicRetirementDate),
+				Description:       "The account still has EC2-Classic enabled in the region, which has been retired. Migrate any remaining EC2-Classic resources, such as Elastic IP addresses, to a VPC, then contact AWS Support to disable EC2-Classic.",
+				ReferenceUrl:      "https://aws.amazon.com/blogs/aws/ec2-classic-is-retiring-heres-how-to-prepare/",
+			}}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findImdsv1Usage lists the instances that still accept IMDSv1 requests
+func findImdsv1Usage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findImdsv1Usage", "connection_error", err)
+		return nil, err
+	}
+
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	input := &ec2.DescribeInstancesInput{
+		Filters: []ec2Types.Filter{
+			{Name: aws.String("metadata-options.http-tokens"), Values: []string{"optional"}},
+			{Name: aws.String("metadata-options.http-endpoint"), Values: []string{"enabled"}},
+			{Name: aws.String("instance-state-name"), Values: []string{"pending", "running", "stopping", "stopped"}},
+		},
+	}
+
+	var findings []deprecatedApiUsage
+	paginator := ec2.NewDescribeInstancesPaginator(svc, input, func(o *ec2.DescribeInstancesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_deprecated_api_usage.findImdsv1Usage", "api_error", err)
+			return nil, err
+		}
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				instanceId := aws.ToString(instance.InstanceId)
+				findings = append(findings, deprecatedApiUsage{
+					Category:          deprecatedApiUsageImdsv1,
+					ResourceType:      "ec2_instance",
+					ResourceId:        instanceId,
+					ResourceArn:       aws.String("arn:" + commonColumnData.Partition + ":ec2:" + region + ":" + commonColumnData.AccountId + ":instance/" + instanceId),
+					DeprecatedFeature: "Instance Metadata Service Version 1 (IMDSv1)",
+					CurrentValue:      "http_tokens=optional",
+					MigrationTarget:   "IMDSv2 (http_tokens=required)",
+					Description:       "The instance accepts IMDSv1 requests, which do not need a session token and are open to SSRF attacks. Check that nothing on the instance still uses IMDSv1, e.g. with the aws_ec2_instance_imdsv1_usage table, then require IMDSv2.",
+					ReferenceUrl:      "https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/instance-metadata-transition-to-version-2.html",
+				})
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+// findTls10PolicyUsage lists the Application and Network Load Balancer
+// listeners with security policies that allow TLS 1.0
+func findTls10PolicyUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := ELBV2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findTls10PolicyUsage", "connection_error", err)
+		return nil, err
+	}
+
+	usage, err := getElbv2SslPolicyUsage(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	listenersByPolicy := usage.(map[string][]string)
+	if len(listenersByPolicy) == 0 {
+		return nil, nil
+	}
+
+	var findings []deprecatedApiUsage
+	params := &elasticloadbalancingv2.DescribeSSLPoliciesInput{
+		PageSize: aws.Int32(400),
+	}
+	pagesLeft := true
+	for pagesLeft {
+		d.WaitForListRateLimit(ctx)
+
+		response, err := svc.DescribeSSLPolicies(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_deprecated_api_usage.findTls10PolicyUsage", "api_error", err)
+			return nil, err
+		}
+
+		for _, policy := range response.SslPolicies {
+			if !tlsPolicyHasProtocol(policy.SslProtocols, "TLSv1") {
+				continue
+			}
+			for _, listenerArn := range listenersByPolicy[aws.ToString(policy.Name)] {
+				findings = append(findings, deprecatedApiUsage{
+					Category:          deprecatedApiUsageTls10Policy,
+					ResourceType:      "load_balancer_listener",
+					ResourceId:        listenerArn,
+					ResourceArn:       aws.String(listenerArn),
+					DeprecatedFeature: "TLS 1.0",
+					CurrentValue:      aws.ToString(policy.Name),
+					MigrationTarget:   "ELBSecurityPolicy-TLS13-1-2-2021-06",
+					Description:       "The listener's security policy allows TLS 1.0, which is deprecated by RFC 8996. Switch to a policy that requires TLS 1.2 or later once clients no longer negotiate older versions.",
+					ReferenceUrl:      "https://docs.aws.amazon.com/elasticloadbalancing/latest/application/describe-ssl-policies.html",
+				})
+			}
+		}
+
+		pagesLeft = response.NextMarker != nil
+		params.Marker = response.NextMarker
+	}
+
+	return findings, nil
+}
+
+// findDeprecatedLambdaRuntimeUsage lists the Lambda functions using runtimes
+// that are deprecated or scheduled for deprecation
+func findDeprecatedLambdaRuntimeUsage(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) ([]deprecatedApiUsage, error) {
+	svc, err := LambdaClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_deprecated_api_usage.findDeprecatedLambdaRuntimeUsage", "connection_error", err)
+		return nil, err
+	}
+	// Unsupported region check
+	if svc == nil {
+		return nil, nil
+	}
+
+	var findings []deprecatedApiUsage
+	paginator := lambda.NewListFunctionsPaginator(svc, &lambda.ListFunctionsInput{}, func(o *lambda.ListFunctionsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_deprecated_api_usage.findDeprecatedLambdaRuntimeUsage", "api_error", err)
+			return nil, err
+		}
+		for _, function := range output.Functions {
+			runtime := string(function.Runtime)
+			deprecation, ok := deprecatedLambdaRuntimes[runtime]
+			if !ok {
+				continue
+			}
+			findings = append(findings, deprecatedApiUsage{
+				Category:          deprecatedApiUsageLambdaRuntime,
+				ResourceType:      "lambda_function",
+				ResourceId:        aws.ToString(function.FunctionName),
+				ResourceArn:       function.FunctionArn,
+				DeprecatedFeature: "Lambda runtime " + runtime,
+				CurrentValue:      runtime,
+				MigrationTarget:   deprecation.MigrationTarget,
+				Deadline:          aws.Time(deprecation.Deadline),
+				Description:       "Functions using a deprecated runtime no longer get security patches or support, and AWS later blocks creating and updating them. Update the function code for the newer runtime and change the function's runtime setting.",
+				ReferenceUrl:      "https://docs.aws.amazon.com/lambda/latest/dg/lambda-runtimes.html",
+			})
+		}
+	}
+
+	return findings, nil
+}
+
+func tlsPolicyHasProtocol(protocols []string, protocol string) bool {
+	for _, p := range protocols {
+		if p == protocol {
+			return true
+		}
+	}
+	return false
+}
@@ -39,6 +39,10 @@ func tableAwsDocDBCluster(_ context.Context) *plugin.Table {
 				Func: getDocDBClusterTags,
 				Tags: map[string]string{"service": "docdb-elastic", "action": "ListTagsForResource"},
 			},
+			{
+				Func: getDocDBClusterPendingMaintenanceAction,
+				Tags: map[string]string{"service": "rds", "action": "DescribePendingMaintenanceActions"},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(docdbv1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -199,6 +203,13 @@ func tableAwsDocDBCluster(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("DBClusterMembers"),
 			},
+			{
+				Name:        "pending_maintenance_actions",
+				Description: "A list that provides details about the pending maintenance actions for the cluster.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDocDBClusterPendingMaintenanceAction,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "read_replica_identifiers",
 				Description: "A list of identifiers of the read replicas associated with this cluster.",
@@ -362,6 +373,33 @@ func getDocDBClusterTags(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	return op, nil
 }
 
+func getDocDBClusterPendingMaintenanceAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	cluster := h.Item.(types.DBCluster)
+
+	// Create Session
+	svc, err := DocDBClient(ctx, d)
+	if err != nil {
+		logger.Error("aws_docdb_cluster.getDocDBClusterPendingMaintenanceAction", "service_creation_error", err)
+		return nil, err
+	}
+
+	params := &docdb.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: cluster.DBClusterArn,
+	}
+
+	op, err := svc.DescribePendingMaintenanceActions(ctx, params)
+	if err != nil {
+		logger.Error("aws_docdb_cluster.getDocDBClusterPendingMaintenanceAction", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.PendingMaintenanceActions) > 0 {
+		return op.PendingMaintenanceActions, nil
+	}
+	return nil, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func docDBClusterTagListToTurbotTags(ctx context.Context, d *transform.TransformData) (interface{}, error) {
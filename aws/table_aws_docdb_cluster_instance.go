@@ -39,6 +39,10 @@ func tableAwsDocDBClusterInstance(_ context.Context) *plugin.Table {
 				Func: getDocDBClusterInstanceTags,
 				Tags: map[string]string{"service": "docdb", "action": "ListTagsForResource"},
 			},
+			{
+				Func: getDocDBClusterInstancePendingMaintenanceAction,
+				Tags: map[string]string{"service": "rds", "action": "DescribePendingMaintenanceActions"},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(docdbv1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -93,6 +97,12 @@ func tableAwsDocDBClusterInstance(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("CACertificateIdentifier"),
 			},
+			{
+				Name:        "certificate_valid_till",
+				Description: "The expiration date of the instance's server certificate.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("CertificateDetails.ValidTill"),
+			},
 			{
 				Name:        "copy_tags_to_snapshot",
 				Description: "Specifies whether tags are copied from the DB instance to snapshots of the DB instance, or not.",
@@ -203,6 +213,13 @@ func tableAwsDocDBClusterInstance(_ context.Context) *plugin.Table {
 				Description: "A list of log types that this instance is configured to export to CloudWatch Logs.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "pending_maintenance_actions",
+				Description: "A list that provides details about the pending maintenance actions for the instance.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getDocDBClusterInstancePendingMaintenanceAction,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "pending_modified_values",
 				Description: "Specifies that changes to the instance are pending.",
@@ -349,6 +366,32 @@ func getDocDBClusterInstanceTags(ctx context.Context, d *plugin.QueryData, h *pl
 	return op.TagList, nil
 }
 
+func getDocDBClusterInstancePendingMaintenanceAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(types.DBInstance)
+
+	// Create Session
+	svc, err := DocDBClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_docdb_cluster_instance.getDocDBClusterInstancePendingMaintenanceAction", "client_error", err)
+		return nil, err
+	}
+
+	params := &docdb.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: instance.DBInstanceArn,
+	}
+
+	op, err := svc.DescribePendingMaintenanceActions(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_docdb_cluster_instance.getDocDBClusterInstancePendingMaintenanceAction", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.PendingMaintenanceActions) > 0 {
+		return op.PendingMaintenanceActions, nil
+	}
+	return nil, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func DocDBClusterInstanceTagListToTurbotTags(ctx context.Context, d *transform.TransformData) (interface{}, error) {
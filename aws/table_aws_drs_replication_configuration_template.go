@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/drs"
+
+	drsv1 "github.com/aws/aws-sdk-go/service/drs"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsDRSReplicationConfigurationTemplate(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_drs_replication_configuration_template",
+		Description: "AWS DRS Replication Configuration Template",
+		List: &plugin.ListConfig{
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "replication_configuration_template_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				// UninitializedAccountException - This error comes up when default replication settings are not set for a particular region.
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"UninitializedAccountException", "BadRequestException"}),
+			},
+			Hydrate: listAwsDRSReplicationConfigurationTemplates,
+			Tags:    map[string]string{"service": "drs", "action": "DescribeReplicationConfigurationTemplates"},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(drsv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "replication_configuration_template_id",
+				Description: "The ID of the replication configuration template.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReplicationConfigurationTemplateID"),
+			},
+			{
+				Name:        "arn",
+				Description: "The ARN of the replication configuration template.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "associate_default_security_group",
+				Description: "Whether to associate the default Elastic Disaster Recovery security group with the replication configuration template.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "auto_replicate_new_disks",
+				Description: "Whether to allow the AWS replication agent to automatically replicate newly added disks.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "bandwidth_throttling",
+				Description: "Configure bandwidth throttling for the outbound data transfer rate of the source server in Mbps.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "create_public_ip",
+				Description: "Whether to create a public IP for the recovery instance by default.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "data_plane_routing",
+				Description: "The data plane routing mechanism that will be used for replication. Possible values are: PRIVATE_IP and PUBLIC_IP.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "default_large_staging_disk_type",
+				Description: "The staging disk EBS volume type to be used during replication.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ebs_encryption",
+				Description: "The type of EBS encryption to be used during replication. Possible values are: DEFAULT, CUSTOM and NONE.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ebs_encryption_key_arn",
+				Description: "The ARN of the EBS encryption key to be used during replication.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "replication_server_instance_type",
+				Description: "The instance type to be used for the replication server.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "staging_area_subnet_id",
+				Description: "The subnet to be used by the replication staging area.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "use_dedicated_replication_server",
+				Description: "Whether to use a dedicated replication server in the replication staging area.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "pit_policy",
+				Description: "The point in time (PIT) policy to manage snapshots taken during replication.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "replication_servers_security_groups_ids",
+				Description: "The security group IDs that will be used by the replication server.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ReplicationServersSecurityGroupsIDs"),
+			},
+			{
+				Name:        "staging_area_tags",
+				Description: "A set of tags to be associated with all resources created in the replication staging area: EC2 replication server, EBS volumes, EBS snapshots, etc.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReplicationConfigurationTemplateID"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsDRSReplicationConfigurationTemplates(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	svc, err := DRSClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_drs_replication_configuration_template.listAwsDRSReplicationConfigurationTemplates", "connection_error", err)
+		return nil, err
+	}
+
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	maxItems := int32(1000)
+	input := drs.DescribeReplicationConfigurationTemplatesInput{}
+
+	// Reduce the basic request limit down if the user has only requested a small number of rows
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxItems {
+			if limit < 1 {
+				maxItems = int32(1)
+			} else {
+				maxItems = int32(limit)
+			}
+		}
+	}
+
+	input.MaxResults = aws.Int32(maxItems)
+	if templateID := d.EqualsQualString("replication_configuration_template_id"); templateID != "" {
+		input.ReplicationConfigurationTemplateIDs = []string{templateID}
+	}
+
+	paginator := drs.NewDescribeReplicationConfigurationTemplatesPaginator(svc, &input, func(o *drs.DescribeReplicationConfigurationTemplatesPaginatorOptions) {
+		o.Limit = maxItems
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_drs_replication_configuration_template.listAwsDRSReplicationConfigurationTemplates", "api_error", err)
+			return nil, err
+		}
+
+		for _, template := range output.Items {
+			d.StreamListItem(ctx, template)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
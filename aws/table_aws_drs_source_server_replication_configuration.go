@@ -0,0 +1,184 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/drs"
+	"github.com/aws/aws-sdk-go-v2/service/drs/types"
+
+	drsv1 "github.com/aws/aws-sdk-go/service/drs"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type drsSourceServerReplicationConfiguration struct {
+	SourceServerArn *string
+	*drs.GetReplicationConfigurationOutput
+}
+
+//// TABLE DEFINITION
+
+func tableAwsDRSSourceServerReplicationConfiguration(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_drs_source_server_replication_configuration",
+		Description: "AWS DRS Source Server Replication Configuration",
+		List: &plugin.ListConfig{
+			ParentHydrate: listAwsDRSSourceServers,
+			Hydrate:       listAwsDRSSourceServerReplicationConfigurations,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "source_server_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				// UninitializedAccountException - This error comes up when default replication settings are not set for a particular region.
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"UninitializedAccountException", "BadRequestException", "ResourceNotFoundException"}),
+			},
+			Tags: map[string]string{"service": "drs", "action": "GetReplicationConfiguration"},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(drsv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "source_server_id",
+				Description: "The ID of the Source Server for this replication configuration.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourceServerID"),
+			},
+			{
+				Name:        "source_server_arn",
+				Description: "The ARN of the Source Server for this replication configuration.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "The name of the replication configuration.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "associate_default_security_group",
+				Description: "Whether to associate the default Elastic Disaster Recovery security group with the replication configuration.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "auto_replicate_new_disks",
+				Description: "Whether to allow the AWS replication agent to automatically replicate newly added disks.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "bandwidth_throttling",
+				Description: "Configure bandwidth throttling for the outbound data transfer rate of the source server in Mbps.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "create_public_ip",
+				Description: "Whether to create a public IP for the recovery instance by default.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "data_plane_routing",
+				Description: "The data plane routing mechanism that will be used for replication. Possible values are: PRIVATE_IP and PUBLIC_IP.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "default_large_staging_disk_type",
+				Description: "The staging disk EBS volume type to be used during replication.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ebs_encryption",
+				Description: "The type of EBS encryption to be used during replication. Possible values are: DEFAULT, CUSTOM and NONE.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ebs_encryption_key_arn",
+				Description: "The ARN of the EBS encryption key to be used during replication.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "replication_server_instance_type",
+				Description: "The instance type to be used for the replication server.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "staging_area_subnet_id",
+				Description: "The subnet to be used by the replication staging area.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "use_dedicated_replication_server",
+				Description: "Whether to use a dedicated replication server in the replication staging area.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "pit_policy",
+				Description: "The point in time (PIT) policy to manage snapshots taken during replication.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "replicated_disks",
+				Description: "The configuration of the disks of the Source Server to be replicated.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "replication_servers_security_groups_ids",
+				Description: "The security group IDs that will be used by the replication server.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("ReplicationServersSecurityGroupsIDs"),
+			},
+			{
+				Name:        "staging_area_tags",
+				Description: "A set of tags to be associated with all resources created in the replication staging area: EC2 replication server, EBS volumes, EBS snapshots, etc.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourceServerID"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("SourceServerArn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsDRSSourceServerReplicationConfigurations(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	sourceServer := h.Item.(types.SourceServer)
+
+	// Create service
+	svc, err := DRSClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_drs_source_server_replication_configuration.listAwsDRSSourceServerReplicationConfigurations", "connection_error", err)
+		return nil, err
+	}
+
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &drs.GetReplicationConfigurationInput{
+		SourceServerID: sourceServer.SourceServerID,
+	}
+
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	replicationConfiguration, err := svc.GetReplicationConfiguration(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_drs_source_server_replication_configuration.listAwsDRSSourceServerReplicationConfigurations", "api_error", err)
+		return nil, err
+	}
+
+	d.StreamListItem(ctx, drsSourceServerReplicationConfiguration{sourceServer.Arn, replicationConfiguration})
+
+	return nil, nil
+}
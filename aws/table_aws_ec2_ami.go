@@ -52,6 +52,12 @@ func tableAwsEc2Ami(_ context.Context) *plugin.Table {
 				{Name: "virtualization_type", Require: plugin.Optional},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getImageBlockPublicAccessState,
+				Tags: map[string]string{"service": "ec2", "action": "GetImageBlockPublicAccessState"},
+			},
+		},
 		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
@@ -141,6 +147,13 @@ func tableAwsEc2Ami(_ context.Context) *plugin.Table {
 				Description: "Indicates whether the image has public launch permissions. The value is true if this image has public launch permissions or false if it has only implicit and explicit launch permissions.",
 				Type:        proto.ColumnType_BOOL,
 			},
+			{
+				Name:        "image_block_public_access_state",
+				Description: "The block public access for AMIs setting of the account in the image's region. If block-new-sharing, the image cannot be newly made public, but an image that was already public remains public.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getImageBlockPublicAccessState,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "ramdisk_id",
 				Description: "The RAM disk associated with the image, if any. Only applicable for machine images.",
@@ -54,6 +54,7 @@ func tableAwsEc2Instance(_ context.Context) *plugin.Table {
 				{Name: "placement_tenancy", Require: plugin.Optional},
 				{Name: "virtualization_type", Require: plugin.Optional},
 				{Name: "vpc_id", Require: plugin.Optional},
+				{Name: "tags", Require: plugin.Optional, Operators: []string{"@>", "?"}},
 			},
 		},
 		HydrateConfig: []plugin.HydrateConfig{
@@ -539,9 +540,11 @@ func listEc2Instance(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrate
 		MaxResults: aws.Int32(maxLimit),
 	}
 	filters := buildEc2InstanceFilter(d.EqualsQuals)
+	filters = append(filters, buildEc2TagFilter(d.Quals)...)
 
 	if len(filters) != 0 {
 		input.Filters = filters
+		plugin.Logger(ctx).Debug("aws_ec2_instance.listEc2Instance", "filters_pushed", filters)
 	}
 
 	paginator := ec2.NewDescribeInstancesPaginator(svc, input, func(o *ec2.DescribeInstancesPaginatorOptions) {
@@ -909,6 +912,26 @@ func buildEc2InstanceFilter(equalQuals plugin.KeyColumnEqualsQualMap) []types.Fi
 	return filters
 }
 
+// buildEc2TagFilter converts quals on the tags column into EC2 tag filters
+func buildEc2TagFilter(quals plugin.KeyColumnQualMap) []types.Filter {
+	filters := make([]types.Filter, 0)
+
+	for _, tagFilter := range getTagQualFilters(quals, "tags") {
+		if tagFilter.Value == nil {
+			filters = append(filters, types.Filter{
+				Name:   aws.String("tag-key"),
+				Values: []string{tagFilter.Key},
+			})
+			continue
+		}
+		filters = append(filters, types.Filter{
+			Name:   aws.String("tag:" + tagFilter.Key),
+			Values: []string{*tagFilter.Value},
+		})
+	}
+	return filters
+}
+
 func getListValues(listValue *proto.QualValueList) []*string {
 	values := make([]*string, 0)
 	if listValue != nil {
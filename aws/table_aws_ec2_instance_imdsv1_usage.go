@@ -0,0 +1,259 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwTypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	ec2v1 "github.com/aws/aws-sdk-go/service/ec2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	// Number of days of MetadataNoToken data points to check when the days qual is not set
+	ec2Imdsv1UsageDefaultDays = 14
+	// CloudWatch keeps daily data points for 455 days
+	ec2Imdsv1UsageMaxDays = 455
+)
+
+//// TABLE DEFINITION
+
+func tableAwsEc2InstanceImdsv1Usage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ec2_instance_imdsv1_usage",
+		Description: "AWS EC2 Instance IMDSv1 Usage",
+		List: &plugin.ListConfig{
+			ParentHydrate: listEc2Instance,
+			Hydrate:       listEc2InstanceImdsv1Usage,
+			Tags:          map[string]string{"service": "ec2", "action": "DescribeInstances"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "days", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getEc2InstanceMetadataNoTokenUsage,
+				Tags: map[string]string{"service": "cloudwatch", "action": "GetMetricStatistics"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "instance_id",
+				Description: "The ID of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_state",
+				Description: "The state of the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "http_tokens",
+				Description: "Indicates whether IMDSv2 is required (required) or IMDSv1 is still allowed (optional).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "http_endpoint",
+				Description: "Indicates whether the instance metadata service is enabled or disabled.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "allows_imdsv1",
+				Description: "True if the metadata service is enabled and does not require session tokens, i.e. IMDSv1 requests are accepted.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "days",
+				Description: "The number of days of MetadataNoToken metric data checked. Defaults to 14, up to 455.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "metadata_no_token_count",
+				Description: "The number of IMDSv1 requests (MetadataNoToken metric) made by the instance in the last days. Null if the instance does not allow IMDSv1.",
+				Type:        proto.ColumnType_DOUBLE,
+				Hydrate:     getEc2InstanceMetadataNoTokenUsage,
+				Transform:   transform.FromField("MetadataNoTokenCount"),
+			},
+			{
+				Name:        "last_metadata_no_token_date",
+				Description: "The start of the most recent day in which the instance made IMDSv1 requests.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getEc2InstanceMetadataNoTokenUsage,
+				Transform:   transform.FromField("LastMetadataNoTokenDate"),
+			},
+			{
+				Name:        "imdsv1_usage_status",
+				Description: "The IMDSv1 usage of the instance. Possible values are: enforced (IMDSv2 is required or the metadata service is disabled), allowed_unused (IMDSv1 is allowed but was not used in the last days) and allowed_in_use (IMDSv1 is allowed and was used in the last days).",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getEc2InstanceMetadataNoTokenUsage,
+				Transform:   transform.FromField("Status"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("InstanceId"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(getEc2InstanceImdsv1UsageTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getEc2InstanceImdsv1UsageAkas,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+type ec2InstanceImdsv1Usage struct {
+	InstanceId    *string
+	InstanceState types.InstanceStateName
+	HttpTokens    types.HttpTokensState
+	HttpEndpoint  types.InstanceMetadataEndpointState
+	AllowsImdsv1  bool
+	Days          int64
+	Tags          []types.Tag
+}
+
+type ec2InstanceMetadataNoTokenUsage struct {
+	MetadataNoTokenCount    *float64
+	LastMetadataNoTokenDate *time.Time
+	Status                  string
+}
+
+//// LIST FUNCTION
+
+func listEc2InstanceImdsv1Usage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	instance := h.Item.(types.Instance)
+
+	days := int64(ec2Imdsv1UsageDefaultDays)
+	if d.EqualsQuals["days"] != nil {
+		days = d.EqualsQuals["days"].GetInt64Value()
+		if days < 1 || days > ec2Imdsv1UsageMaxDays {
+			return nil, fmt.Errorf("days must be between 1 and %d", ec2Imdsv1UsageMaxDays)
+		}
+	}
+
+	item := ec2InstanceImdsv1Usage{
+		InstanceId: instance.InstanceId,
+		Days:       days,
+		Tags:       instance.Tags,
+	}
+	if instance.State != nil {
+		item.InstanceState = instance.State.Name
+	}
+	if instance.MetadataOptions != nil {
+		item.HttpTokens = instance.MetadataOptions.HttpTokens
+		item.HttpEndpoint = instance.MetadataOptions.HttpEndpoint
+		item.AllowsImdsv1 = item.HttpTokens == types.HttpTokensStateOptional && item.HttpEndpoint != types.InstanceMetadataEndpointStateDisabled
+	}
+
+	d.StreamListItem(ctx, item)
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getEc2InstanceMetadataNoTokenUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	item := h.Item.(ec2InstanceImdsv1Usage)
+
+	// The metric is only relevant while the instance accepts IMDSv1 requests
+	if !item.AllowsImdsv1 {
+		return &ec2InstanceMetadataNoTokenUsage{Status: "enforced"}, nil
+	}
+
+	// Create Session
+	svc, err := CloudWatchClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_instance_imdsv1_usage.getEc2InstanceMetadataNoTokenUsage", "connection_error", err)
+		return nil, err
+	}
+
+	endTime := time.Now()
+	params := &cloudwatch.GetMetricStatisticsInput{
+		Namespace:  aws.String("AWS/EC2"),
+		MetricName: aws.String("MetadataNoToken"),
+		StartTime:  aws.Time(endTime.AddDate(0, 0, -int(item.Days))),
+		EndTime:    aws.Time(endTime),
+		Period:     aws.Int32(86400),
+		Statistics: []cwTypes.Statistic{cwTypes.StatisticSum},
+		Dimensions: []cwTypes.Dimension{
+			{
+				Name:  aws.String("InstanceId"),
+				Value: item.InstanceId,
+			},
+		},
+	}
+
+	stats, err := svc.GetMetricStatistics(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_instance_imdsv1_usage.getEc2InstanceMetadataNoTokenUsage", "api_error", err)
+		return nil, err
+	}
+
+	usage := &ec2InstanceMetadataNoTokenUsage{
+		MetadataNoTokenCount: aws.Float64(0),
+		Status:               "allowed_unused",
+	}
+	for _, datapoint := range stats.Datapoints {
+		if datapoint.Sum == nil || *datapoint.Sum == 0 {
+			continue
+		}
+		*usage.MetadataNoTokenCount += *datapoint.Sum
+		if usage.LastMetadataNoTokenDate == nil || datapoint.Timestamp.After(*usage.LastMetadataNoTokenDate) {
+			usage.LastMetadataNoTokenDate = datapoint.Timestamp
+		}
+		usage.Status = "allowed_in_use"
+	}
+
+	return usage, nil
+}
+
+func getEc2InstanceImdsv1UsageAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+	item := h.Item.(ec2InstanceImdsv1Usage)
+
+	commonData, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	commonColumnData := commonData.(*awsCommonColumnData)
+
+	akas := []string{"arn:" + commonColumnData.Partition + ":ec2:" + region + ":" + commonColumnData.AccountId + ":instance/" + *item.InstanceId}
+
+	return akas, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func getEc2InstanceImdsv1UsageTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	item := d.HydrateItem.(ec2InstanceImdsv1Usage)
+	if item.Tags == nil {
+		return nil, nil
+	}
+
+	turbotTagsMap := map[string]string{}
+	for _, i := range item.Tags {
+		turbotTagsMap[*i.Key] = *i.Value
+	}
+
+	return &turbotTagsMap, nil
+}
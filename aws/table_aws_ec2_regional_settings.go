@@ -3,6 +3,7 @@ package aws
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
@@ -12,6 +13,7 @@ import (
 	"github.com/aws/smithy-go"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
@@ -38,6 +40,14 @@ func tableAwsEc2RegionalSettings(_ context.Context) *plugin.Table {
 				Func: getSnapshotBlockPublicAccessState,
 				Tags: map[string]string{"service": "ec2", "action": "GetSnapshotBlockPublicAccessState"},
 			},
+			{
+				Func: getImageBlockPublicAccessState,
+				Tags: map[string]string{"service": "ec2", "action": "GetImageBlockPublicAccessState"},
+			},
+			{
+				Func: getSerialConsoleAccessStatus,
+				Tags: map[string]string{"service": "ec2", "action": "GetSerialConsoleAccessStatus"},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -62,6 +72,20 @@ func tableAwsEc2RegionalSettings(_ context.Context) *plugin.Table {
 				Hydrate:     getSnapshotBlockPublicAccessState,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "image_block_public_access_state",
+				Description: "The current state of block public access for AMIs setting for the account and Region. If block-new-sharing, new public sharing of AMIs is blocked; if unblocked, AMIs can be publicly shared.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getImageBlockPublicAccessState,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "serial_console_access_enabled",
+				Description: "Indicates whether access to the EC2 serial console of instances is enabled for the account and Region. Principals also need the ec2-instance-connect:SendSerialConsoleSSHPublicKey permission to connect.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getSerialConsoleAccessStatus,
+				Transform:   transform.FromValue(),
+			},
 
 			// Steampipe standard columns
 			{
@@ -147,6 +171,54 @@ func getSnapshotBlockPublicAccessState(ctx context.Context, d *plugin.QueryData,
 	return result.State, nil
 }
 
+// The AMI block public access state is an account and region level setting,
+// but it is also used per row by aws_ec2_ami, so cache it per region.
+var getImageBlockPublicAccessStateMemoized = plugin.HydrateFunc(getImageBlockPublicAccessStateUncached).Memoize(memoize.WithCacheKeyFunction(getImageBlockPublicAccessStateCacheKey))
+
+func getImageBlockPublicAccessState(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getImageBlockPublicAccessStateMemoized(ctx, d, h)
+}
+
+func getImageBlockPublicAccessStateCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+	key := fmt.Sprintf("getImageBlockPublicAccessState-%s", region)
+	return key, nil
+}
+
+func getImageBlockPublicAccessStateUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_regional_settings.getImageBlockPublicAccessState", "connection_error", err)
+		return nil, err
+	}
+	params := &ec2.GetImageBlockPublicAccessStateInput{}
+	result, err := svc.GetImageBlockPublicAccessState(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_regional_settings.getImageBlockPublicAccessState", "api_error", err)
+		return nil, err
+	}
+	return result.ImageBlockPublicAccessState, nil
+}
+
+func getSerialConsoleAccessStatus(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_regional_settings.getSerialConsoleAccessStatus", "connection_error", err)
+		return nil, err
+	}
+	params := &ec2.GetSerialConsoleAccessStatusInput{}
+	result, err := svc.GetSerialConsoleAccessStatus(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ec2_regional_settings.getSerialConsoleAccessStatus", "api_error", err)
+		return nil, err
+	}
+	return result.SerialConsoleAccessEnabled, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func getEc2SettingTitle(ctx context.Context, d *transform.TransformData) (interface{}, error) {
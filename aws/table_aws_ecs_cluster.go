@@ -109,6 +109,12 @@ func tableAwsEcsCluster(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Hydrate:     getEcsCluster,
 			},
+			{
+				Name:        "service_connect_defaults",
+				Description: "The default Service Connect namespace of the cluster, used by services that enable Service Connect without naming a namespace.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getEcsCluster,
+			},
 			{
 				Name:        "settings",
 				Description: "The settings for the cluster. This parameter indicates whether CloudWatch Container Insights is enabled or disabled for a cluster.",
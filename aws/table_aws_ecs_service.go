@@ -10,6 +10,7 @@ import (
 
 	ecsv1 "github.com/aws/aws-sdk-go/service/ecs"
 
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -184,6 +185,36 @@ func tableAwsEcsService(_ context.Context) *plugin.Table {
 				Description: "The details of the service discovery registries to assign to this service.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "service_connect_enabled",
+				Description: "Indicates whether Service Connect is enabled for the primary deployment of the service.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.FromP(ecsServiceConnectConfiguration, "Enabled"),
+			},
+			{
+				Name:        "service_connect_namespace",
+				Description: "The Cloud Map namespace the service connects to and is discovered in, with Service Connect.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromP(ecsServiceConnectConfiguration, "Namespace"),
+			},
+			{
+				Name:        "service_connect_services",
+				Description: "The Service Connect endpoints the service exposes to other services in the namespace, including their client aliases and TLS configuration.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromP(ecsServiceConnectConfiguration, "Services"),
+			},
+			{
+				Name:        "service_connect_resources",
+				Description: "The mapping of the Service Connect discovery names of the service to Cloud Map services.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(ecsServiceConnectResources),
+			},
+			{
+				Name:        "service_connect_tls_enabled",
+				Description: "True if every Service Connect endpoint the service exposes requires TLS. Null if the service exposes no Service Connect endpoints.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(ecsServiceConnectTlsEnabled),
+			},
 			{
 				Name:        "task_sets",
 				Description: "Information about a set of Amazon ECS tasks in either an AWS CodeDeploy or an EXTERNAL deployment.",
@@ -374,3 +405,51 @@ func getEcsServiceTurbotTags(_ context.Context, d *transform.TransformData) (int
 	}
 	return turbotTagsMap, nil
 }
+
+// ecsServicePrimaryDeployment returns the most recent deployment of the
+// service, whose configuration is the one the service converges on
+func ecsServicePrimaryDeployment(service types.Service) *types.Deployment {
+	for _, deployment := range service.Deployments {
+		if aws.ToString(deployment.Status) == "PRIMARY" {
+			return &deployment
+		}
+	}
+	return nil
+}
+
+// ecsServiceConnectConfiguration returns the field named by the transform
+// param from the Service Connect configuration of the primary deployment
+func ecsServiceConnectConfiguration(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	deployment := ecsServicePrimaryDeployment(d.HydrateItem.(types.Service))
+	if deployment == nil || deployment.ServiceConnectConfiguration == nil {
+		return nil, nil
+	}
+	value, _ := helpers.GetFieldValueFromInterface(*deployment.ServiceConnectConfiguration, d.Param.(string))
+	return value, nil
+}
+
+func ecsServiceConnectResources(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	deployment := ecsServicePrimaryDeployment(d.HydrateItem.(types.Service))
+	if deployment == nil || len(deployment.ServiceConnectResources) == 0 {
+		return nil, nil
+	}
+	return deployment.ServiceConnectResources, nil
+}
+
+func ecsServiceConnectTlsEnabled(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	deployment := ecsServicePrimaryDeployment(d.HydrateItem.(types.Service))
+	if deployment == nil || deployment.ServiceConnectConfiguration == nil {
+		return nil, nil
+	}
+
+	config := deployment.ServiceConnectConfiguration
+	if !config.Enabled || len(config.Services) == 0 {
+		return nil, nil
+	}
+	for _, service := range config.Services {
+		if service.Tls == nil {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -85,6 +85,24 @@ func tableAwsEfsAccessPoint(_ context.Context) *plugin.Table {
 				Description: "The directory on the Amazon EFS file system that the access point exposes as the root directory to NFS clients using the access point.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "posix_user_enforced",
+				Description: "True if the access point overrides the user and group IDs of NFS clients with the POSIX user.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(efsAccessPointPosixUserEnforced),
+			},
+			{
+				Name:        "posix_user_is_root",
+				Description: "True if the access point enforces the root user (user ID 0), giving clients full access to the files under the root directory. Null if no POSIX user is enforced.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(efsAccessPointPosixUserIsRoot),
+			},
+			{
+				Name:        "root_directory_path",
+				Description: "The path on the file system exposed to clients as the root directory. A path of / exposes the whole file system.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RootDirectory.Path"),
+			},
 			{
 				Name:        "tags_src",
 				Description: "The tags associated with the access point, presented as an array of Tag objects.",
@@ -242,3 +260,19 @@ func efsAccessPointTitle(ctx context.Context, d *transform.TransformData) (inter
 
 	return data.AccessPointId, nil
 }
+
+func efsAccessPointPosixUserEnforced(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	data := d.HydrateItem.(types.AccessPointDescription)
+
+	return data.PosixUser != nil, nil
+}
+
+func efsAccessPointPosixUserIsRoot(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	data := d.HydrateItem.(types.AccessPointDescription)
+
+	if data.PosixUser == nil || data.PosixUser.Uid == nil {
+		return nil, nil
+	}
+
+	return *data.PosixUser.Uid == 0, nil
+}
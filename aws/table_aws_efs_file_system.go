@@ -11,9 +11,12 @@ import (
 
 	"github.com/aws/smithy-go"
 
+	go_kit_pack "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
 )
 
 //// TABLE DEFINITION
@@ -136,6 +139,13 @@ func tableAwsElasticFileSystem(_ context.Context) *plugin.Table {
 				Hydrate:     getElasticFileSystemPolicy,
 				Transform:   transform.FromField("Policy").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "policy_enforces_secure_transport",
+				Description: "True if the file system policy denies mounting to all principals when aws:SecureTransport is false, so clients must mount the file system using TLS. Null if the file system has no policy.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getElasticFileSystemPolicy,
+				Transform:   transform.FromField("Policy").Transform(unescape).Transform(efsPolicyEnforcesSecureTransport),
+			},
 			{
 				Name:        "tags_src",
 				Description: "A list of tags associated with Filesystem.",
@@ -340,3 +350,21 @@ func automaticBackupsValue(_ context.Context, d *transform.TransformData) (inter
 	}
 	return "disabled", nil
 }
+
+// efsPolicyEnforcesSecureTransport returns true if the file system policy
+// denies mounting over plain NFS. The Deny must apply to all principals and be
+// conditioned only on aws:SecureTransport being false, otherwise some clients
+// can still mount without TLS.
+func efsPolicyEnforcesSecureTransport(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	document := go_kit_pack.SafeString(d.Value)
+	if document == "" {
+		return nil, nil
+	}
+
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		return nil, err
+	}
+
+	return policyeval.DeniesInsecureTransport(policy.(Policy), "elasticfilesystem:clientmount"), nil
+}
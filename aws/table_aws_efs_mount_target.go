@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/efs"
 	"github.com/aws/aws-sdk-go-v2/service/efs/types"
 
@@ -39,6 +41,11 @@ func tableAwsEfsMountTarget(_ context.Context) *plugin.Table {
 				Func: getAwsEfsMountTargetSecurityGroup,
 				Tags: map[string]string{"service": "elasticfilesystem", "action": "DescribeMountTargetSecurityGroups"},
 			},
+			{
+				Func:    getAwsEfsMountTargetNfsIngress,
+				Depends: []plugin.HydrateFunc{getAwsEfsMountTargetSecurityGroup},
+				Tags:    map[string]string{"service": "ec2", "action": "DescribeSecurityGroups"},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(efsv1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -98,6 +105,20 @@ func tableAwsEfsMountTarget(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Hydrate:     getAwsEfsMountTargetSecurityGroup,
 			},
+			{
+				Name:        "nfs_ingress_rules",
+				Description: "The inbound rules of the mount target's security groups that allow NFS traffic (TCP port 2049), with the source of each rule.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsEfsMountTargetNfsIngress,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "nfs_ingress_open_to_world",
+				Description: "True if a security group of the mount target allows NFS traffic from any IPv4 or IPv6 address.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsEfsMountTargetNfsIngress,
+				Transform:   transform.From(efsMountTargetNfsIngressOpenToWorld),
+			},
 
 			// Steampipe standard columns
 			{
@@ -246,8 +267,106 @@ func getAwsEfsMountTargetSecurityGroup(ctx context.Context, d *plugin.QueryData,
 	return op, nil
 }
 
+// efsNfsIngressRule is an inbound security group rule allowing NFS traffic.
+// Only one of the source fields is set.
+type efsNfsIngressRule struct {
+	GroupId               string
+	IpProtocol            string
+	FromPort              *int32
+	ToPort                *int32
+	CidrIpv4              *string `json:"CidrIpv4,omitempty"`
+	CidrIpv6              *string `json:"CidrIpv6,omitempty"`
+	PrefixListId          *string `json:"PrefixListId,omitempty"`
+	SourceSecurityGroupId *string `json:"SourceSecurityGroupId,omitempty"`
+}
+
+// getAwsEfsMountTargetNfsIngress resolves the security groups of the mount
+// target and returns their inbound rules that allow NFS traffic
+func getAwsEfsMountTargetNfsIngress(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	securityGroups, ok := h.HydrateResults["getAwsEfsMountTargetSecurityGroup"].(*efs.DescribeMountTargetSecurityGroupsOutput)
+	if !ok || securityGroups == nil || len(securityGroups.SecurityGroups) == 0 {
+		return []efsNfsIngressRule{}, nil
+	}
+
+	// Create service
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_efs_mount_target.getAwsEfsMountTargetNfsIngress", "connection_error", err)
+		return nil, err
+	}
+
+	params := &ec2.DescribeSecurityGroupsInput{
+		GroupIds: securityGroups.SecurityGroups,
+	}
+
+	op, err := svc.DescribeSecurityGroups(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_efs_mount_target.getAwsEfsMountTargetNfsIngress", "api_error", err)
+		return nil, err
+	}
+
+	rules := []efsNfsIngressRule{}
+	for _, group := range op.SecurityGroups {
+		for _, permission := range group.IpPermissions {
+			if !ipPermissionAllowsTcpPort(permission, 2049) {
+				continue
+			}
+			rule := efsNfsIngressRule{
+				GroupId:    *group.GroupId,
+				IpProtocol: *permission.IpProtocol,
+				FromPort:   permission.FromPort,
+				ToPort:     permission.ToPort,
+			}
+			for _, r := range permission.IpRanges {
+				rule.CidrIpv4 = r.CidrIp
+				rules = append(rules, rule)
+			}
+			rule.CidrIpv4 = nil
+			for _, r := range permission.Ipv6Ranges {
+				rule.CidrIpv6 = r.CidrIpv6
+				rules = append(rules, rule)
+			}
+			rule.CidrIpv6 = nil
+			for _, r := range permission.PrefixListIds {
+				rule.PrefixListId = r.PrefixListId
+				rules = append(rules, rule)
+			}
+			rule.PrefixListId = nil
+			for _, r := range permission.UserIdGroupPairs {
+				rule.SourceSecurityGroupId = r.GroupId
+				rules = append(rules, rule)
+			}
+		}
+	}
+
+	return rules, nil
+}
+
+// ipPermissionAllowsTcpPort returns true if the rule allows TCP traffic to the
+// port, either directly or because it allows all traffic
+func ipPermissionAllowsTcpPort(permission ec2types.IpPermission, port int32) bool {
+	switch aws.ToString(permission.IpProtocol) {
+	case "-1":
+		return true
+	case "tcp", "6":
+		return permission.FromPort != nil && permission.ToPort != nil && *permission.FromPort <= port && port <= *permission.ToPort
+	}
+	return false
+}
+
 //// TRANSFORM FUNCTION
 
+func efsMountTargetNfsIngressOpenToWorld(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	rules := d.HydrateItem.([]efsNfsIngressRule)
+
+	for _, rule := range rules {
+		if aws.ToString(rule.CidrIpv4) == "0.0.0.0/0" || aws.ToString(rule.CidrIpv6) == "::/0" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func getAwsEfsMountTargetAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	region := d.EqualsQualString(matrixKeyRegion)
 	data := h.Item.(types.MountTargetDescription)
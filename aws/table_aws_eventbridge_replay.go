@@ -0,0 +1,245 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+
+	eventbridgev1 "github.com/aws/aws-sdk-go/service/eventbridge"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+func tableAwsEventBridgeReplay(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_eventbridge_replay",
+		Description: "AWS EventBridge Replay",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "ValidationException"}),
+			},
+			Hydrate: getAwsEventBridgeReplay,
+			Tags:    map[string]string{"service": "events", "action": "DescribeReplay"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listAwsEventBridgeReplays,
+			Tags:    map[string]string{"service": "events", "action": "ListReplays"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "event_source_arn", Require: plugin.Optional},
+				{Name: "state", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getAwsEventBridgeReplay,
+				Tags: map[string]string{"service": "events", "action": "DescribeReplay"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(eventbridgev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the replay.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReplayName"),
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the replay.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAwsEventBridgeReplay,
+				Transform:   transform.FromField("ReplayArn"),
+			},
+			{
+				Name:        "state",
+				Description: "The current state of the replay. Possible values are: STARTING, RUNNING, CANCELLING, COMPLETED, CANCELLED or FAILED.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_reason",
+				Description: "The reason that the replay is in the current state.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "The description of the replay.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAwsEventBridgeReplay,
+			},
+			{
+				Name:        "event_source_arn",
+				Description: "The ARN of the archive that events are replayed from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "event_start_time",
+				Description: "The time stamp of the first event that was last replayed from the archive.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "event_end_time",
+				Description: "The time stamp for the last event that was replayed from the archive.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "event_last_replayed_time",
+				Description: "The time that the event was last replayed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "replay_start_time",
+				Description: "The time that the replay started.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "replay_end_time",
+				Description: "The time that the replay completed.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "destination",
+				Description: "The destination of the replay, i.e. the event bus and optionally the rules that the events are sent to.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsEventBridgeReplay,
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ReplayName"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsEventBridgeReplay,
+				Transform:   transform.FromField("ReplayArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsEventBridgeReplays(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Get client
+	svc, err := EventBridgeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_eventbridge_replay.listAwsEventBridgeReplays", "get_client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 1 {
+				maxLimit = 1
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	pagesLeft := true
+	params := &eventbridge.ListReplaysInput{
+		// Default to the maximum allowed
+		Limit: aws.Int32(maxLimit),
+	}
+
+	// Additonal Filter
+	if d.EqualsQualString("event_source_arn") != "" {
+		params.EventSourceArn = aws.String(d.EqualsQualString("event_source_arn"))
+	}
+	if d.EqualsQualString("state") != "" {
+		params.State = types.ReplayState(d.EqualsQualString("state"))
+	}
+
+	// API doesn't support aws-go-sdk-v2 paginator as of date
+	for pagesLeft {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := svc.ListReplays(ctx, params)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_eventbridge_replay.listAwsEventBridgeReplays", "api_error", err)
+			return nil, err
+		}
+
+		for _, replay := range output.Replays {
+			d.StreamListItem(ctx, &eventbridge.DescribeReplayOutput{
+				ReplayName:            replay.ReplayName,
+				EventEndTime:          replay.EventEndTime,
+				EventLastReplayedTime: replay.EventLastReplayedTime,
+				EventSourceArn:        replay.EventSourceArn,
+				EventStartTime:        replay.EventStartTime,
+				ReplayEndTime:         replay.ReplayEndTime,
+				ReplayStartTime:       replay.ReplayStartTime,
+				State:                 replay.State,
+				StateReason:           replay.StateReason,
+			})
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if output.NextToken != nil {
+			pagesLeft = true
+			params.NextToken = output.NextToken
+		} else {
+			pagesLeft = false
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getAwsEventBridgeReplay(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var name string
+	if h.Item != nil {
+		name = *h.Item.(*eventbridge.DescribeReplayOutput).ReplayName
+	} else {
+		name = d.EqualsQualString("name")
+	}
+
+	// Create Session
+	svc, err := EventBridgeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_eventbridge_replay.getAwsEventBridgeReplay", "get_client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Build the params
+	params := &eventbridge.DescribeReplayInput{
+		ReplayName: aws.String(name),
+	}
+
+	// Get call
+	data, err := svc.DescribeReplay(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_eventbridge_replay.getAwsEventBridgeReplay", "api_error", err)
+		return nil, err
+	}
+
+	return data, nil
+}
@@ -161,7 +161,12 @@ func listAccessAdvisor(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		if resp.JobStatus == "IN_PROGRESS" && retryNumber < maxRetries {
 			retryNumber++
 			plugin.Logger(ctx).Debug("GetServiceLastAccessedDetails in progress", "retryNumber", retryNumber)
-			time.Sleep(retryIntervalMs * time.Millisecond)
+			// Stop waiting if the query is cancelled or times out
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(retryIntervalMs * time.Millisecond):
+			}
 			continue
 		}
 
@@ -181,7 +186,7 @@ func listAccessAdvisor(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 			// Context may get cancelled due to manual cancellation or if the limit has been reached
 			if d.RowsRemaining(ctx) == 0 {
-				break
+				return nil, nil
 			}
 		}
 		if !resp.IsTruncated {
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"sort"
 	"strings"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -58,30 +59,51 @@ func tableAwsIamAction(_ context.Context) *plugin.Table {
 				Description: "The description for this action.",
 				Transform:   transform.FromGo(),
 			},
+			{
+				Name:        "supports_resource_level_permissions",
+				Type:        proto.ColumnType_BOOL,
+				Description: "If true, the action can be scoped to specific resources. If false, the action requires a wildcard (*) in the Resource element of a policy statement.",
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "condition_keys",
+				Type:        proto.ColumnType_JSON,
+				Description: "A list of condition keys supported by this action.",
+				Transform:   transform.FromGo(),
+			},
+			{
+				Name:        "resource_types",
+				Type:        proto.ColumnType_JSON,
+				Description: "A list of resource types supported by this action, including their condition keys and dependent actions. Resource types with a trailing * are required.",
+				Transform:   transform.FromGo(),
+			},
 		},
 	}
 }
 
 type awsIamPermissionData struct {
-	Action      string
-	Prefix      string
-	Privilege   string
-	AccessLevel string
-	Description string
+	Action                           string
+	Prefix                           string
+	Privilege                        string
+	AccessLevel                      string
+	Description                      string
+	SupportsResourceLevelPermissions bool
+	ConditionKeys                    []string
+	ResourceTypes                    []ParliamentResourceType
 }
 
 //// LIST FUNCTION
 
 func listIamActions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
-	for _, service := range permissionsData {
+	permissions, err := getIamPermissionsData(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_action.listIamActions", "data_source_error", err)
+		return nil, err
+	}
+
+	for _, service := range permissions {
 		for _, privilege := range service.Privileges {
-			d.StreamListItem(ctx, awsIamPermissionData{
-				AccessLevel: privilege.AccessLevel,
-				Action:      strings.ToLower(service.Prefix + ":" + privilege.Privilege),
-				Description: privilege.Description,
-				Prefix:      service.Prefix,
-				Privilege:   privilege.Privilege,
-			})
+			d.StreamListItem(ctx, buildIamPermissionData(service, privilege))
 
 			// Context can be cancelled due to manual cancellation or the limit has been hit
 			if d.RowsRemaining(ctx) == 0 {
@@ -97,19 +119,48 @@ func listIamActions(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateD
 func getIamAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	action := d.EqualsQuals["action"].GetStringValue()
 
-	for _, service := range permissionsData {
+	permissions, err := getIamPermissionsData(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_action.getIamAction", "data_source_error", err)
+		return nil, err
+	}
+
+	for _, service := range permissions {
 		for _, privilege := range service.Privileges {
 			a := strings.ToLower(service.Prefix + ":" + privilege.Privilege)
 			if a == strings.ToLower(action) {
-				return awsIamPermissionData{
-					AccessLevel: privilege.AccessLevel,
-					Action:      a,
-					Description: privilege.Description,
-					Prefix:      service.Prefix,
-					Privilege:   privilege.Privilege,
-				}, nil
+				return buildIamPermissionData(service, privilege), nil
 			}
 		}
 	}
 	return nil, nil
 }
+
+//// UTILITY FUNCTIONS
+
+// buildIamPermissionData flattens a Parliament privilege into a row. An action
+// supports resource-level permissions if at least one of its resource types is
+// named; actions whose only resource type is empty must use "Resource": "*".
+func buildIamPermissionData(service ParliamentService, privilege ParliamentPrivilege) awsIamPermissionData {
+	supportsResourceLevelPermissions := false
+	conditionKeys := []string{}
+	for _, resourceType := range privilege.ResourceTypes {
+		if resourceType.ResourceType != "" {
+			supportsResourceLevelPermissions = true
+		}
+		conditionKeys = append(conditionKeys, resourceType.ConditionKeys...)
+	}
+	conditionKeys = uniqueStrings(conditionKeys)
+	sort.Strings(conditionKeys)
+
+	return awsIamPermissionData{
+		AccessLevel:                      privilege.AccessLevel,
+		Action:                           strings.ToLower(service.Prefix + ":" + privilege.Privilege),
+		Description:                      privilege.Description,
+		Prefix:                           service.Prefix,
+		Privilege:                        privilege.Privilege,
+		SupportsResourceLevelPermissions: supportsResourceLevelPermissions,
+		ConditionKeys:                    conditionKeys,
+		ResourceTypes:                    privilege.ResourceTypes,
+	}
+}
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"net/url"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -11,6 +12,8 @@ import (
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
 )
 
 //// TABLE DEFINITION
@@ -42,6 +45,10 @@ func tableAwsIamPolicy(_ context.Context) *plugin.Table {
 				Func: getIamPolicy,
 				Tags: map[string]string{"service": "iam", "action": "GetPolicy"},
 			},
+			{
+				Func:    getIamPolicyAnalysis,
+				Depends: []plugin.HydrateFunc{getPolicyVersion},
+			},
 		},
 		Columns: awsGlobalRegionColumns([]*plugin.Column{
 			{
@@ -122,6 +129,20 @@ func tableAwsIamPolicy(_ context.Context) *plugin.Table {
 				Hydrate:     getPolicyVersion,
 				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "policy_ineffective_statement_ids",
+				Description: "The statements of the policy that cannot allow or deny anything, because none of their actions exist. A Deny statement is not reported just because the policy has no matching Allow, as it still denies access allowed by other policies. Statements are identified by Sid, or by their position in the policy if they have none.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getIamPolicyAnalysis,
+				Transform:   transform.FromField("IneffectiveStatementIds"),
+			},
+			{
+				Name:        "policy_metrics",
+				Description: "The size and complexity of the policy: the number of statements, distinct actions after expanding wildcards, statements using wildcard actions, services and condition keys, and the policy size in characters, excluding whitespace, against the 6,144 character quota.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getIamPolicyAnalysis,
+				Transform:   transform.FromField("Metrics"),
+			},
 			{
 				Name:        "tags_src",
 				Description: "A list of tags attached with the IAM policy.",
@@ -260,6 +281,43 @@ func getPolicyVersion(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 	return version, nil
 }
 
+func getIamPolicyAnalysis(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	version := h.HydrateResults["getPolicyVersion"].(*iam.GetPolicyVersionOutput)
+	if version.PolicyVersion == nil || version.PolicyVersion.Document == nil {
+		return nil, nil
+	}
+
+	document, err := url.QueryUnescape(*version.PolicyVersion.Document)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_policy.getIamPolicyAnalysis", "unescape_error", err)
+		return nil, err
+	}
+
+	policy, err := canonicalPolicy(document)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_policy.getIamPolicyAnalysis", "policy_error", err)
+		return nil, err
+	}
+
+	knownActions, err := getIamKnownActionsCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_policy.getIamPolicyAnalysis", "iam_permissions_error", err)
+		return nil, err
+	}
+	expander, err := getIamActionExpanderCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_policy.getIamPolicyAnalysis", "iam_permissions_error", err)
+		return nil, err
+	}
+
+	// Identity-based policies are not attached to a resource, so there is no
+	// resource to match
+	return iamPolicyAnalysis{
+		IneffectiveStatementIds: policyeval.IneffectiveStatementIds(policy.(Policy), "", knownActions.(policyeval.KnownActions)),
+		Metrics:                 policyeval.PolicyMetrics(policy.(Policy), policyeval.IAMPolicySize(document), policyeval.IAMManagedPolicySizeQuota, expander.(*policyeval.ActionExpander)),
+	}, nil
+}
+
 // isPolicyAwsManaged returns true if policy is aws managed
 func isPolicyAwsManaged(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	policy := h.Item.(types.Policy)
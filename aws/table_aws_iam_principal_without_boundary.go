@@ -0,0 +1,326 @@
+package aws
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	"github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsIamPrincipalWithoutBoundary(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_iam_principal_without_boundary",
+		Description: "AWS IAM Principal Without Boundary",
+		List: &plugin.ListConfig{
+			Hydrate: listIamPrincipalsWithoutBoundary,
+			Tags:    map[string]string{"service": "iam", "action": "GetAccountAuthorizationDetails"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "principal_type", Require: plugin.Optional},
+			},
+		},
+		Columns: awsGlobalRegionColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The friendly name of the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the principal. Possible values are: User or Role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal_id",
+				Description: "The stable and unique string identifying the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "path",
+				Description: "The path to the user or role.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "create_date",
+				Description: "The date and time when the user or role was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "grants_all_actions",
+				Description: "True if one of the granting policies allows all actions (*) on all resources.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "granting_policies",
+				Description: "The policies that allow iam:* on all resources to the principal, including inline and attached policies of the groups a user is a member of.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+type iamPrincipalWithoutBoundary struct {
+	Name             *string
+	Arn              *string
+	PrincipalType    string
+	PrincipalId      *string
+	Path             *string
+	CreateDate       *time.Time
+	GrantsAllActions bool
+	GrantingPolicies []iamWildcardGrantingPolicy
+}
+
+type iamWildcardGrantingPolicy struct {
+	PolicyName string
+	PolicyArn  string `json:"PolicyArn,omitempty"`
+	PolicyType string
+	GroupName  string `json:"GroupName,omitempty"`
+}
+
+// iamAuthorizationPolicy is a policy document from the account authorization
+// details, along with what it grants
+type iamAuthorizationPolicy struct {
+	grantsIamWildcard bool
+	grantsAllActions  bool
+}
+
+//// LIST FUNCTION
+
+func listIamPrincipalsWithoutBoundary(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Get client
+	svc, err := IAMClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "client_error", err)
+		return nil, err
+	}
+
+	// Account authorization details return every principal with its permissions
+	// boundary and policies in one paginated call, which is much cheaper than
+	// hydrating each user and role
+	input := &iam.GetAccountAuthorizationDetailsInput{
+		Filter: []types.EntityType{
+			types.EntityTypeUser,
+			types.EntityTypeRole,
+			types.EntityTypeGroup,
+			types.EntityTypeLocalManagedPolicy,
+			types.EntityTypeAWSManagedPolicy,
+		},
+		MaxItems: aws.Int32(1000),
+	}
+	paginator := iam.NewGetAccountAuthorizationDetailsPaginator(svc, input, func(o *iam.GetAccountAuthorizationDetailsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	var users []types.UserDetail
+	var roles []types.RoleDetail
+	groups := map[string]types.GroupDetail{}
+	managedPolicies := map[string]iamAuthorizationPolicy{}
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "api_error", err)
+			return nil, err
+		}
+
+		users = append(users, output.UserDetailList...)
+		roles = append(roles, output.RoleDetailList...)
+		for _, group := range output.GroupDetailList {
+			groups[aws.ToString(group.GroupName)] = group
+		}
+		for _, policy := range output.Policies {
+			for _, version := range policy.PolicyVersionList {
+				if !version.IsDefaultVersion {
+					continue
+				}
+				analysis, err := analyzeIamAuthorizationPolicy(version.Document)
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "policy_error", err, "policy_arn", aws.ToString(policy.Arn))
+					return nil, err
+				}
+				managedPolicies[aws.ToString(policy.Arn)] = analysis
+			}
+		}
+	}
+
+	principalType := d.EqualsQualString("principal_type")
+
+	if principalType == "" || principalType == "User" {
+		for _, user := range users {
+			if user.PermissionsBoundary != nil {
+				continue
+			}
+
+			grants, grantsAll, err := iamWildcardGrantingPolicies(user.UserPolicyList, user.AttachedManagedPolicies, managedPolicies, "")
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "policy_error", err, "user", aws.ToString(user.UserName))
+				return nil, err
+			}
+
+			// Users also get the permissions of the groups they are a member of
+			for _, groupName := range user.GroupList {
+				group, ok := groups[groupName]
+				if !ok {
+					continue
+				}
+				groupGrants, groupGrantsAll, err := iamWildcardGrantingPolicies(group.GroupPolicyList, group.AttachedManagedPolicies, managedPolicies, groupName)
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "policy_error", err, "group", groupName)
+					return nil, err
+				}
+				grants = append(grants, groupGrants...)
+				grantsAll = grantsAll || groupGrantsAll
+			}
+			if len(grants) == 0 {
+				continue
+			}
+
+			d.StreamListItem(ctx, iamPrincipalWithoutBoundary{
+				Name:             user.UserName,
+				Arn:              user.Arn,
+				PrincipalType:    "User",
+				PrincipalId:      user.UserId,
+				Path:             user.Path,
+				CreateDate:       user.CreateDate,
+				GrantsAllActions: grantsAll,
+				GrantingPolicies: grants,
+			})
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	if principalType == "" || principalType == "Role" {
+		for _, role := range roles {
+			if role.PermissionsBoundary != nil {
+				continue
+			}
+
+			grants, grantsAll, err := iamWildcardGrantingPolicies(role.RolePolicyList, role.AttachedManagedPolicies, managedPolicies, "")
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_iam_principal_without_boundary.listIamPrincipalsWithoutBoundary", "policy_error", err, "role", aws.ToString(role.RoleName))
+				return nil, err
+			}
+			if len(grants) == 0 {
+				continue
+			}
+
+			d.StreamListItem(ctx, iamPrincipalWithoutBoundary{
+				Name:             role.RoleName,
+				Arn:              role.Arn,
+				PrincipalType:    "Role",
+				PrincipalId:      role.RoleId,
+				Path:             role.Path,
+				CreateDate:       role.CreateDate,
+				GrantsAllActions: grantsAll,
+				GrantingPolicies: grants,
+			})
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// iamWildcardGrantingPolicies returns the inline and attached policies that
+// grant iam:* on all resources, and whether any of them grants all actions
+func iamWildcardGrantingPolicies(inline []types.PolicyDetail, attached []types.AttachedPolicy, managedPolicies map[string]iamAuthorizationPolicy, groupName string) ([]iamWildcardGrantingPolicy, bool, error) {
+	var grants []iamWildcardGrantingPolicy
+	var grantsAll bool
+
+	for _, policy := range inline {
+		analysis, err := analyzeIamAuthorizationPolicy(policy.PolicyDocument)
+		if err != nil {
+			return nil, false, err
+		}
+		if !analysis.grantsIamWildcard {
+			continue
+		}
+		grants = append(grants, iamWildcardGrantingPolicy{
+			PolicyName: aws.ToString(policy.PolicyName),
+			PolicyType: "inline",
+			GroupName:  groupName,
+		})
+		grantsAll = grantsAll || analysis.grantsAllActions
+	}
+
+	for _, policy := range attached {
+		analysis, ok := managedPolicies[aws.ToString(policy.PolicyArn)]
+		if !ok || !analysis.grantsIamWildcard {
+			continue
+		}
+		grants = append(grants, iamWildcardGrantingPolicy{
+			PolicyName: aws.ToString(policy.PolicyName),
+			PolicyArn:  aws.ToString(policy.PolicyArn),
+			PolicyType: "managed",
+			GroupName:  groupName,
+		})
+		grantsAll = grantsAll || analysis.grantsAllActions
+	}
+
+	return grants, grantsAll, nil
+}
+
+// analyzeIamAuthorizationPolicy checks whether a URL encoded policy document
+// grants iam:* or * on all resources
+func analyzeIamAuthorizationPolicy(document *string) (iamAuthorizationPolicy, error) {
+	if document == nil {
+		return iamAuthorizationPolicy{}, nil
+	}
+
+	decoded, err := url.QueryUnescape(*document)
+	if err != nil {
+		return iamAuthorizationPolicy{}, err
+	}
+
+	policy, err := canonicalPolicy(decoded)
+	if err != nil {
+		return iamAuthorizationPolicy{}, err
+	}
+
+	return iamAuthorizationPolicy{
+		grantsIamWildcard: policyeval.GrantsAction(policy.(Policy), iamWildcardAction),
+		grantsAllActions:  policyeval.GrantsAction(policy.(Policy), "*"),
+	}, nil
+}
@@ -50,6 +50,11 @@ func tableAwsIamRole(ctx context.Context) *plugin.Table {
 				Func: listAwsIamRoleInlinePolicies,
 				Tags: map[string]string{"service": "iam", "action": "ListRolePolicies"},
 			},
+			{
+				Func:    getAwsIamRolePermissionsBoundaryPolicy,
+				Tags:    map[string]string{"service": "iam", "action": "GetPolicyVersion"},
+				Depends: []plugin.HydrateFunc{getIamRole},
+			},
 		},
 		Columns: awsGlobalRegionColumns([]*plugin.Column{
 			// "Key" Columns
@@ -113,6 +118,27 @@ func tableAwsIamRole(ctx context.Context) *plugin.Table {
 				Hydrate:   getIamRole,
 				Transform: transform.FromField("PermissionsBoundary.PermissionsBoundaryType"),
 			},
+			{
+				Name:        "permissions_boundary_policy",
+				Description: "The policy document of the permissions boundary for the role.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRolePermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(transform.UnmarshalYAML),
+			},
+			{
+				Name:        "permissions_boundary_policy_std",
+				Description: "Contains the permissions boundary policy in a canonical form for easier searching.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRolePermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(policyToCanonical),
+			},
+			{
+				Name:        "permissions_boundary_restricts_admin_actions",
+				Description: "True if the permissions boundary stops the role using iam:* or *, either by not allowing them or by explicitly denying them. Null if the role has no permissions boundary.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsIamRolePermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(permissionsBoundaryRestrictsAdminActions),
+			},
 			{
 				Name: "role_last_used_date",
 				Type: proto.ColumnType_TIMESTAMP,
@@ -170,6 +196,62 @@ func tableAwsIamRole(ctx context.Context) *plugin.Table {
 				Type:        proto.ColumnType_JSON,
 				Transform:   transform.FromField("AssumeRolePolicyDocument").Transform(unescape).Transform(policyToCanonical),
 			},
+			{
+				Name:        "assume_role_policy_allows_tag_session",
+				Description: "True if the trust policy allows principals to pass session tags (sts:TagSession) when they assume the role.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("AllowsTagSession"),
+			},
+			{
+				Name:        "assume_role_policy_allows_set_source_identity",
+				Description: "True if the trust policy allows principals to set a source identity (sts:SetSourceIdentity) when they assume the role.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("AllowsSetSourceIdentity"),
+			},
+			{
+				Name:        "assume_role_policy_session_tagging_enforced",
+				Description: "True if every trust policy statement allowing sts:TagSession has an aws:RequestTag or aws:TagKeys condition. Null if session tagging is not allowed.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("SessionTaggingEnforced"),
+			},
+			{
+				Name:        "assume_role_policy_session_conditions",
+				Description: "The trust policy conditions on session tags and source identity (aws:RequestTag, aws:TagKeys, sts:TransitiveTagKeys and sts:SourceIdentity).",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("SessionConditions"),
+			},
+			{
+				Name:        "assume_role_policy_cross_account_principals",
+				Description: "The AWS principals from other accounts that the trust policy allows to assume the role, excluding well-known AWS owned accounts and the accounts in the trusted_accounts connection config argument.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("CrossAccountPrincipals"),
+			},
+			{
+				Name:        "assume_role_policy_wellknown_principals",
+				Description: "The AWS principals in well-known AWS owned accounts, such as service log delivery accounts, that the trust policy allows to assume the role. See the aws_wellknown_public_account table.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("WellKnownPrincipals"),
+			},
+			{
+				Name:        "assume_role_policy_deleted_principal_ids",
+				Description: "The unique IDs of deleted users and roles that the trust policy still allows to assume the role. IAM replaces the ARN of a deleted principal with its unique ID, so these statements no longer grant access.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("DeletedPrincipalIds"),
+			},
+			{
+				Name:        "assume_role_policy_ineffective_statement_ids",
+				Description: "The statements of the trust policy that cannot allow or deny anything, because none of their actions exist or all of their principals are deleted users or roles. A Deny statement is not reported just because the policy has no matching Allow, as it still denies access allowed by other policies. Statements are identified by Sid, or by their position in the policy if they have none.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamRoleTrustPolicySessionControls,
+				Transform:   transform.FromField("IneffectiveStatementIds"),
+			},
 
 			// Standard columns for all tables
 			{
@@ -461,6 +543,21 @@ func getRoleInlinePolicy(ctx context.Context, policyName *string, roleName *stri
 	return rolePolicy, nil
 }
 
+func getAwsIamRolePermissionsBoundaryPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	role := h.HydrateResults["getIamRole"].(types.Role)
+	if role.PermissionsBoundary == nil {
+		return nil, nil
+	}
+
+	version, err := getIamPermissionsBoundaryPolicyVersion(ctx, d, aws.ToString(role.PermissionsBoundary.PermissionsBoundaryArn))
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_role.getAwsIamRolePermissionsBoundaryPolicy", "api_error", err)
+		return nil, err
+	}
+
+	return version, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func getIamRoleTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
@@ -63,6 +63,11 @@ func tableAwsIamUser(ctx context.Context) *plugin.Table {
 				Func: listAwsIamUserInlinePolicies,
 				Tags: map[string]string{"service": "iam", "action": "ListUserPolicies"},
 			},
+			{
+				Func:    getAwsIamUserPermissionsBoundaryPolicy,
+				Tags:    map[string]string{"service": "iam", "action": "GetPolicyVersion"},
+				Depends: []plugin.HydrateFunc{getAwsIamUserData},
+			},
 		},
 		Columns: awsGlobalRegionColumns([]*plugin.Column{
 			{
@@ -109,6 +114,27 @@ func tableAwsIamUser(ctx context.Context) *plugin.Table {
 				Type:    proto.ColumnType_STRING,
 				Hydrate: getAwsIamUserData,
 			},
+			{
+				Name:        "permissions_boundary_policy",
+				Description: "The policy document of the permissions boundary for the user.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamUserPermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(transform.UnmarshalYAML),
+			},
+			{
+				Name:        "permissions_boundary_policy_std",
+				Description: "Contains the permissions boundary policy in a canonical form for easier searching.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getAwsIamUserPermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(policyToCanonical),
+			},
+			{
+				Name:        "permissions_boundary_restricts_admin_actions",
+				Description: "True if the permissions boundary stops the user using iam:* or *, either by not allowing them or by explicitly denying them. Null if the user has no permissions boundary.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getAwsIamUserPermissionsBoundaryPolicy,
+				Transform:   transform.FromField("PolicyVersion.Document").Transform(unescape).Transform(permissionsBoundaryRestrictsAdminActions),
+			},
 			{
 				Name:        "mfa_enabled",
 				Description: "The MFA status of the user.",
@@ -320,8 +346,13 @@ func getAwsIamUserData(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		UserName: user.UserName,
 	}
 
-	userData, _ := svc.GetUser(ctx, params)
+	userData, err := svc.GetUser(ctx, params)
 	if err != nil {
+		// The user was deleted since it was listed
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchEntity" {
+			return nil, nil
+		}
 		plugin.Logger(ctx).Error("aws_iam_user.getAwsIamUserData", "api_error", err)
 		return nil, err
 	}
@@ -353,6 +384,25 @@ func getAwsIamUserData(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	}, nil
 }
 
+func getAwsIamUserPermissionsBoundaryPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	userData, ok := h.HydrateResults["getAwsIamUserData"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	boundaryArn := userData["PermissionsBoundaryArn"].(string)
+	if boundaryArn == "" {
+		return nil, nil
+	}
+
+	version, err := getIamPermissionsBoundaryPolicyVersion(ctx, d, boundaryArn)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_iam_user.getAwsIamUserPermissionsBoundaryPolicy", "api_error", err)
+		return nil, err
+	}
+
+	return version, nil
+}
+
 func getAwsIamUserAttachedPolicies(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	user := h.Item.(types.User)
 
@@ -367,8 +417,13 @@ func getAwsIamUserAttachedPolicies(ctx context.Context, d *plugin.QueryData, h *
 		UserName: user.UserName,
 	}
 
-	userData, _ := svc.ListAttachedUserPolicies(ctx, params)
+	userData, err := svc.ListAttachedUserPolicies(ctx, params)
 	if err != nil {
+		// The user was deleted since it was listed
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchEntity" {
+			return nil, nil
+		}
 		plugin.Logger(ctx).Error("aws_iam_user.getAwsIamUserAttachedPolicies", "api_error", err)
 		return nil, err
 	}
@@ -398,8 +453,13 @@ func getAwsIamUserGroups(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		UserName: user.UserName,
 	}
 
-	userData, _ := svc.ListGroupsForUser(ctx, params)
+	userData, err := svc.ListGroupsForUser(ctx, params)
 	if err != nil {
+		// The user was deleted since it was listed
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchEntity" {
+			return nil, nil
+		}
 		plugin.Logger(ctx).Error("aws_iam_user.getAwsIamUserGroups", "api_error", err)
 		return nil, err
 	}
@@ -421,8 +481,13 @@ func getAwsIamUserMfaDevices(ctx context.Context, d *plugin.QueryData, h *plugin
 		UserName: user.UserName,
 	}
 
-	userData, _ := svc.ListMFADevices(ctx, params)
+	userData, err := svc.ListMFADevices(ctx, params)
 	if err != nil {
+		// The user was deleted since it was listed
+		var ae smithy.APIError
+		if errors.As(err, &ae) && ae.ErrorCode() == "NoSuchEntity" {
+			return nil, nil
+		}
 		plugin.Logger(ctx).Error("aws_iam_user.getAwsIamUserMfaDevices", "api_error", err)
 		return nil, err
 	}
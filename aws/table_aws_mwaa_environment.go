@@ -0,0 +1,365 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/mwaa"
+	"github.com/aws/aws-sdk-go-v2/service/mwaa/types"
+
+	mwaav1 "github.com/aws/aws-sdk-go/service/mwaa"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// End of support dates of Apache Airflow versions on Amazon MWAA.
+// https://docs.aws.amazon.com/mwaa/latest/userguide/airflow-versions.html
+var mwaaAirflowVersionEndOfSupport = map[string]time.Time{
+	"1.10.12": time.Date(2022, 12, 30, 0, 0, 0, 0, time.UTC),
+	"2.0.2":   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+	"2.2.2":   time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+	"2.4.3":   time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC),
+}
+
+//// TABLE DEFINITION
+
+func tableAwsMWAAEnvironment(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_mwaa_environment",
+		Description: "AWS MWAA Environment",
+		Get: &plugin.GetConfig{
+			KeyColumns: plugin.SingleColumn("name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException", "ValidationException"}),
+			},
+			Hydrate: getMWAAEnvironment,
+			Tags:    map[string]string{"service": "airflow", "action": "GetEnvironment"},
+		},
+		List: &plugin.ListConfig{
+			Hydrate: listMWAAEnvironments,
+			Tags:    map[string]string{"service": "airflow", "action": "ListEnvironments"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getMWAAEnvironment,
+				Tags: map[string]string{"service": "airflow", "action": "GetEnvironment"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(mwaav1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "name",
+				Description: "The name of the Amazon MWAA environment.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Environment.Name"),
+			},
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the Amazon MWAA environment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.Arn"),
+			},
+			{
+				Name:        "status",
+				Description: "The status of the Amazon MWAA environment, e.g. AVAILABLE, CREATING, UPDATING or UNAVAILABLE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.Status"),
+			},
+			{
+				Name:        "created_at",
+				Description: "The day and time the environment was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.CreatedAt"),
+			},
+			{
+				Name:        "airflow_version",
+				Description: "The Apache Airflow version on the environment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.AirflowVersion"),
+			},
+			{
+				Name:        "airflow_version_end_of_support_date",
+				Description: "The date Amazon MWAA ends support for the Apache Airflow version of the environment. Null if no end of support date has been announced.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.AirflowVersion").Transform(mwaaAirflowVersionEndOfSupportDate),
+			},
+			{
+				Name:        "airflow_version_end_of_support",
+				Description: "True if Amazon MWAA no longer supports the Apache Airflow version of the environment.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.AirflowVersion").Transform(mwaaAirflowVersionIsEndOfSupport),
+			},
+			{
+				Name:        "environment_class",
+				Description: "The environment class type, e.g. mw1.small.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.EnvironmentClass"),
+			},
+			{
+				Name:        "webserver_access_mode",
+				Description: "The Apache Airflow web server access mode. Possible values are: PUBLIC_ONLY (the web server is reachable from the internet) or PRIVATE_ONLY (the web server is only reachable from the VPC).",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.WebserverAccessMode"),
+			},
+			{
+				Name:        "webserver_url",
+				Description: "The Apache Airflow web server host name for the environment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.WebserverUrl"),
+			},
+			{
+				Name:        "endpoint_management",
+				Description: "Whether the VPC endpoints of the environment are created and managed by the customer (CUSTOMER) or by Amazon MWAA (SERVICE).",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.EndpointManagement"),
+			},
+			{
+				Name:        "execution_role_arn",
+				Description: "The ARN of the execution role in IAM that allows MWAA to access Amazon Web Services resources in your environment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.ExecutionRoleArn"),
+			},
+			{
+				Name:        "service_role_arn",
+				Description: "The ARN of the service-linked role of the environment.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.ServiceRoleArn"),
+			},
+			{
+				Name:        "kms_key",
+				Description: "The KMS encryption key used to encrypt the data in the environment. Null if an AWS owned key is used.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.KmsKey"),
+			},
+			{
+				Name:        "source_bucket_arn",
+				Description: "The ARN of the Amazon S3 bucket where your DAG code and supporting files are stored.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.SourceBucketArn"),
+			},
+			{
+				Name:        "dag_s3_path",
+				Description: "The relative path to the DAGs folder in the Amazon S3 bucket.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.DagS3Path"),
+			},
+			{
+				Name:        "min_workers",
+				Description: "The minimum number of workers that run in the environment.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.MinWorkers"),
+			},
+			{
+				Name:        "max_workers",
+				Description: "The maximum number of workers that run in the environment.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.MaxWorkers"),
+			},
+			{
+				Name:        "schedulers",
+				Description: "The number of Apache Airflow schedulers that run in the environment.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.Schedulers"),
+			},
+			{
+				Name:        "weekly_maintenance_window_start",
+				Description: "The day and time of the week in UTC that weekly maintenance updates are scheduled, e.g. TUE:03:30.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.WeeklyMaintenanceWindowStart"),
+			},
+			{
+				Name:        "airflow_configuration_options",
+				Description: "The Apache Airflow configuration options set on the environment.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.AirflowConfigurationOptions"),
+			},
+			{
+				Name:        "last_update",
+				Description: "The status of the last update on the environment, and any errors that were encountered.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.LastUpdate"),
+			},
+			{
+				Name:        "logging_configuration",
+				Description: "The Apache Airflow log types published to CloudWatch Logs.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.LoggingConfiguration"),
+			},
+			{
+				Name:        "network_configuration",
+				Description: "The VPC security groups and subnets of the environment.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.NetworkConfiguration"),
+			},
+
+			// Standard columns for all tables
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Environment.Name"),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.Tags"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getMWAAEnvironment,
+				Transform:   transform.FromField("Environment.Arn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listMWAAEnvironments(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := MWAAClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_mwaa_environment.listMWAAEnvironments", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	// Limiting the results
+	maxLimit := int32(25)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			if limit < 1 {
+				maxLimit = 1
+			} else {
+				maxLimit = limit
+			}
+		}
+	}
+
+	input := &mwaa.ListEnvironmentsInput{
+		MaxResults: aws.Int32(maxLimit),
+	}
+
+	paginator := mwaa.NewListEnvironmentsPaginator(svc, input, func(o *mwaa.ListEnvironmentsPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_mwaa_environment.listMWAAEnvironments", "api_error", err)
+			return nil, err
+		}
+
+		for _, name := range output.Environments {
+			d.StreamListItem(ctx, &mwaa.GetEnvironmentOutput{
+				Environment: &types.Environment{
+					Name: aws.String(name),
+				},
+			})
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getMWAAEnvironment(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	var name string
+	if h.Item != nil {
+		name = *h.Item.(*mwaa.GetEnvironmentOutput).Environment.Name
+	} else {
+		name = d.EqualsQualString("name")
+	}
+
+	// Create session
+	svc, err := MWAAClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_mwaa_environment.getMWAAEnvironment", "connection_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region, return no data
+		return nil, nil
+	}
+
+	params := &mwaa.GetEnvironmentInput{
+		Name: aws.String(name),
+	}
+
+	op, err := svc.GetEnvironment(ctx, params)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_mwaa_environment.getMWAAEnvironment", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func mwaaAirflowVersionEndOfSupportDate(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	version, ok := d.Value.(*string)
+	if !ok || version == nil {
+		return nil, nil
+	}
+	if date, ok := mwaaAirflowVersionEndOfSupport[*version]; ok {
+		return date, nil
+	}
+	return nil, nil
+}
+
+func mwaaAirflowVersionIsEndOfSupport(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	version, ok := d.Value.(*string)
+	if !ok || version == nil {
+		return nil, nil
+	}
+	if date, ok := mwaaAirflowVersionEndOfSupport[*version]; ok {
+		return time.Now().After(date), nil
+	}
+	return false, nil
+}
@@ -37,6 +37,10 @@ func tableAwsNeptuneDBCluster(_ context.Context) *plugin.Table {
 				Func: getNeptuneDBClusterTags,
 				Tags: map[string]string{"service": "neptune", "action": "ListTagsForResource"},
 			},
+			{
+				Func: getNeptuneDBClusterPendingMaintenanceAction,
+				Tags: map[string]string{"service": "neptune", "action": "DescribePendingMaintenanceActions"},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(neptunev1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -217,6 +221,13 @@ func tableAwsNeptuneDBCluster(_ context.Context) *plugin.Table {
 				Description: "A list of log types that this DB cluster is configured to export to CloudWatch Logs.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "pending_maintenance_actions",
+				Description: "A list that provides details about the pending maintenance actions for the DB cluster.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getNeptuneDBClusterPendingMaintenanceAction,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "read_replica_identifiers",
 				Description: "Contains one or more identifiers of the Read Replicas associated with this DB cluster.",
@@ -381,6 +392,32 @@ func getNeptuneDBClusterTags(ctx context.Context, d *plugin.QueryData, h *plugin
 	return tags, nil
 }
 
+func getNeptuneDBClusterPendingMaintenanceAction(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	clusterArn := h.Item.(types.DBCluster).DBClusterArn
+
+	// Create session
+	svc, err := NeptuneClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_neptune_db_cluster.getNeptuneDBClusterPendingMaintenanceAction", "get_client_error", err)
+		return nil, err
+	}
+
+	input := &neptune.DescribePendingMaintenanceActionsInput{
+		ResourceIdentifier: clusterArn,
+	}
+
+	op, err := svc.DescribePendingMaintenanceActions(ctx, input)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_neptune_db_cluster.getNeptuneDBClusterPendingMaintenanceAction", "api_error", err)
+		return nil, err
+	}
+
+	if len(op.PendingMaintenanceActions) > 0 {
+		return op.PendingMaintenanceActions, nil
+	}
+	return nil, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func neptuneDBClusterTurbotTags(ctx context.Context, d *transform.TransformData) (interface{}, error) {
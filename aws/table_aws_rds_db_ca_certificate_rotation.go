@@ -0,0 +1,320 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+
+	rdsv1 "github.com/aws/aws-sdk-go/service/rds"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// CA certificates that AWS has retired. Instances still using them need to be
+// rotated even before the CA itself expires.
+var rdsDeprecatedCACertificates = []string{"rds-ca-2015", "rds-ca-2019"}
+
+// The pending maintenance action AWS schedules to rotate the CA of a DB instance
+const rdsCACertificateRotationAction = "ca-certificate-rotation"
+
+//// TABLE DEFINITION
+
+func tableAwsRDSDBCACertificateRotation(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_rds_db_ca_certificate_rotation",
+		Description: "AWS RDS DB CA Certificate Rotation",
+		List: &plugin.ListConfig{
+			Hydrate: listRDSDBCACertificateRotations,
+			Tags:    map[string]string{"service": "rds", "action": "DescribeDBInstances"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "engine", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(rdsv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "db_instance_identifier",
+				Description: "The friendly name to identify the DB instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "db_instance_arn",
+				Description: "The Amazon Resource Name (ARN) for the DB instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "db_cluster_identifier",
+				Description: "The name of the DB cluster that the DB instance is a member of, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "engine",
+				Description: "The database engine of the DB instance. RDS, Aurora, DocumentDB and Neptune instances are all listed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "engine_version",
+				Description: "The version of the database engine.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ca_certificate_identifier",
+				Description: "The identifier of the CA certificate used by the DB instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ca_valid_till",
+				Description: "The date the CA certificate expires. Null if the CA is no longer returned by DescribeCertificates.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "deprecation_reason",
+				Description: "Why the CA is considered deprecated. Possible values are: retired (a CA AWS has replaced, e.g. rds-ca-2019), expired, or unavailable (the CA is no longer offered in the region).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "certificate_valid_till",
+				Description: "The expiration date of the DB instance's server certificate.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "default_ca_certificate_identifier",
+				Description: "The CA certificate used for new DB instances in the region, which is the CA the instance would be rotated to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "preferred_maintenance_window",
+				Description: "The weekly time range (in UTC) during which a scheduled rotation is applied.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rotation_scheduled",
+				Description: "True if AWS has scheduled a ca-certificate-rotation pending maintenance action for the DB instance.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "rotation_opt_in_status",
+				Description: "The opt-in status of the scheduled rotation, e.g. immediate or next-maintenance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rotation_auto_applied_after_date",
+				Description: "The date of the maintenance window after which the rotation is applied automatically.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "rotation_forced_apply_date",
+				Description: "The date when the rotation is applied regardless of the maintenance window.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "rotation_current_apply_date",
+				Description: "The effective date when the rotation is applied, taking the opt-in status, auto applied after date and forced apply date into account.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("DbInstanceIdentifier"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("DbInstanceArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+type rdsDBCACertificateRotation struct {
+	DbInstanceIdentifier           *string
+	DbInstanceArn                  *string
+	DbClusterIdentifier            *string
+	Engine                         *string
+	EngineVersion                  *string
+	CaCertificateIdentifier        *string
+	CaValidTill                    *time.Time
+	DeprecationReason              string
+	CertificateValidTill           *time.Time
+	DefaultCaCertificateIdentifier *string
+	PreferredMaintenanceWindow     *string
+	RotationScheduled              bool
+	RotationOptInStatus            *string
+	RotationAutoAppliedAfterDate   *time.Time
+	RotationForcedApplyDate        *time.Time
+	RotationCurrentApplyDate       *time.Time
+}
+
+//// LIST FUNCTION
+
+func listRDSDBCACertificateRotations(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create service
+	svc, err := RDSClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_rds_db_ca_certificate_rotation.listRDSDBCACertificateRotations", "connection_error", err)
+		return nil, err
+	}
+
+	certificates, defaultCertificate, err := listRDSCACertificates(ctx, d, svc)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_rds_db_ca_certificate_rotation.listRDSDBCACertificateRotations", "api_error", err)
+		return nil, err
+	}
+
+	rotations, err := listRDSCACertificateRotationActions(ctx, d, svc)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_rds_db_ca_certificate_rotation.listRDSDBCACertificateRotations", "api_error", err)
+		return nil, err
+	}
+
+	// DocumentDB and Neptune instances are managed through the RDS API, so they
+	// are returned alongside RDS and Aurora instances
+	input := &rds.DescribeDBInstancesInput{
+		MaxRecords: aws.Int32(100),
+	}
+	if d.EqualsQualString("engine") != "" {
+		input.Filters = []types.Filter{
+			{
+				Name:   aws.String("engine"),
+				Values: []string{d.EqualsQualString("engine")},
+			},
+		}
+	}
+
+	paginator := rds.NewDescribeDBInstancesPaginator(svc, input, func(o *rds.DescribeDBInstancesPaginatorOptions) {
+		o.Limit = 100
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_rds_db_ca_certificate_rotation.listRDSDBCACertificateRotations", "api_error", err)
+			return nil, err
+		}
+
+		for _, instance := range output.DBInstances {
+			if instance.CACertificateIdentifier == nil {
+				continue
+			}
+
+			certificate, ok := certificates[*instance.CACertificateIdentifier]
+			var reason string
+			switch {
+			case helpers.StringSliceContains(rdsDeprecatedCACertificates, *instance.CACertificateIdentifier):
+				reason = "retired"
+			case !ok:
+				reason = "unavailable"
+			case certificate.ValidTill != nil && certificate.ValidTill.Before(time.Now()):
+				reason = "expired"
+			default:
+				continue
+			}
+
+			item := rdsDBCACertificateRotation{
+				DbInstanceIdentifier:           instance.DBInstanceIdentifier,
+				DbInstanceArn:                  instance.DBInstanceArn,
+				DbClusterIdentifier:            instance.DBClusterIdentifier,
+				Engine:                         instance.Engine,
+				EngineVersion:                  instance.EngineVersion,
+				CaCertificateIdentifier:        instance.CACertificateIdentifier,
+				CaValidTill:                    certificate.ValidTill,
+				DeprecationReason:              reason,
+				DefaultCaCertificateIdentifier: defaultCertificate,
+				PreferredMaintenanceWindow:     instance.PreferredMaintenanceWindow,
+			}
+			if instance.CertificateDetails != nil {
+				item.CertificateValidTill = instance.CertificateDetails.ValidTill
+			}
+			if action, ok := rotations[aws.ToString(instance.DBInstanceArn)]; ok {
+				item.RotationScheduled = true
+				item.RotationOptInStatus = action.OptInStatus
+				item.RotationAutoAppliedAfterDate = action.AutoAppliedAfterDate
+				item.RotationForcedApplyDate = action.ForcedApplyDate
+				item.RotationCurrentApplyDate = action.CurrentApplyDate
+			}
+
+			d.StreamListItem(ctx, item)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// listRDSCACertificates returns the CA certificates offered in the region,
+// keyed by identifier, and the CA used for new DB instances
+func listRDSCACertificates(ctx context.Context, d *plugin.QueryData, svc *rds.Client) (map[string]types.Certificate, *string, error) {
+	certificates := map[string]types.Certificate{}
+	var defaultCertificate *string
+
+	paginator := rds.NewDescribeCertificatesPaginator(svc, &rds.DescribeCertificatesInput{}, func(o *rds.DescribeCertificatesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		if output.DefaultCertificateForNewLaunches != nil {
+			defaultCertificate = output.DefaultCertificateForNewLaunches
+		}
+		for _, certificate := range output.Certificates {
+			if certificate.CertificateIdentifier != nil {
+				certificates[*certificate.CertificateIdentifier] = certificate
+			}
+		}
+	}
+
+	return certificates, defaultCertificate, nil
+}
+
+// listRDSCACertificateRotationActions returns the pending CA rotations in the
+// region, keyed by the ARN of the DB instance
+func listRDSCACertificateRotationActions(ctx context.Context, d *plugin.QueryData, svc *rds.Client) (map[string]types.PendingMaintenanceAction, error) {
+	rotations := map[string]types.PendingMaintenanceAction{}
+
+	paginator := rds.NewDescribePendingMaintenanceActionsPaginator(svc, &rds.DescribePendingMaintenanceActionsInput{}, func(o *rds.DescribePendingMaintenanceActionsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range output.PendingMaintenanceActions {
+			for _, action := range resource.PendingMaintenanceActionDetails {
+				if aws.ToString(action.Action) == rdsCACertificateRotationAction {
+					rotations[aws.ToString(resource.ResourceIdentifier)] = action
+				}
+			}
+		}
+	}
+
+	return rotations, nil
+}
@@ -123,6 +123,18 @@ func tableAwsRDSDBCluster(_ context.Context) *plugin.Table {
 				Description: "The current capacity of an Aurora Serverless DB cluster.",
 				Type:        proto.ColumnType_INT,
 			},
+			{
+				Name:        "ca_certificate_identifier",
+				Description: "The identifier of the CA certificate used for the DB cluster's server certificate. Only set for Multi-AZ DB clusters.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("CertificateDetails.CAIdentifier"),
+			},
+			{
+				Name:        "certificate_valid_till",
+				Description: "The expiration date of the DB cluster's server certificate.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("CertificateDetails.ValidTill"),
+			},
 			{
 				Name:        "character_set_name",
 				Description: "Specifies the name of the character set that this cluster is associated with.",
@@ -116,6 +116,12 @@ func tableAwsRDSDBInstance(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("CACertificateIdentifier"),
 			},
+			{
+				Name:        "certificate_valid_till",
+				Description: "The expiration date of the DB instance's server certificate.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("CertificateDetails.ValidTill"),
+			},
 			{
 				Name:        "character_set_name",
 				Description: "Specifies the name of the character set that this instance is associated with.",
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -31,6 +32,13 @@ func tableAwsRegion(_ context.Context) *plugin.Table {
 				Description: "The Region opt-in status. The possible values are opt-in-not-required, opted-in, and not-opted-in",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "skip_reason",
+				Description: "The reason the region is not queried by this connection, or null if it is queried or the check failed, e.g. because it was throttled. Possible values are not_in_connection_regions, not_opted_in and sts_endpoint_disabled.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getAwsRegionSkipReason,
+				Transform:   transform.FromValue(),
+			},
 			{
 				Name:        "title",
 				Description: resourceInterfaceDescription("title"),
@@ -65,6 +73,36 @@ func listAwsRegions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateD
 	return nil, nil
 }
 
+func getAwsRegionSkipReason(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := h.Item.(types.Region)
+
+	queryRegions, err := listQueryRegionsForConnection(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_region.getAwsRegionSkipReason", "query_regions_error", err)
+		return nil, err
+	}
+	if !helpers.StringSliceContains(queryRegions, *region.RegionName) {
+		if region.OptInStatus != nil && *region.OptInStatus == "not-opted-in" {
+			return regionSkipReasonNotOptedIn, nil
+		}
+		return regionSkipReasonNotInConnectionRegions, nil
+	}
+
+	// The region is queried, but API calls in it may still fail if the
+	// credentials cannot be used there. Other errors, e.g. throttling or
+	// timeouts, do not tell either way, so leave the reason unknown rather
+	// than fail the query.
+	reason, err := getRegionSkipReason(ctx, d, *region.RegionName)
+	if err != nil {
+		plugin.Logger(ctx).Warn("aws_region.getAwsRegionSkipReason", "region", *region.RegionName, "api_error", err)
+		return nil, nil
+	}
+	if reason == "" {
+		return nil, nil
+	}
+	return reason, nil
+}
+
 func getAwsRegionAkas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	region := h.Item.(types.Region)
 
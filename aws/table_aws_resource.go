@@ -0,0 +1,250 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/configservice"
+
+	configservicev1 "github.com/aws/aws-sdk-go/service/configservice"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Properties selected from the AWS Config advanced query resource schema
+const awsResourceSelectProperties = "resourceId, resourceName, resourceType, arn, availabilityZone, resourceCreationTime, configurationItemCaptureTime, configurationItemStatus, tags, configuration, supplementaryConfiguration"
+
+// awsResource is a single result of a SelectResourceConfig query
+type awsResource struct {
+	ResourceId                   *string          `json:"resourceId"`
+	ResourceName                 *string          `json:"resourceName"`
+	ResourceType                 *string          `json:"resourceType"`
+	Arn                          *string          `json:"arn"`
+	AvailabilityZone             *string          `json:"availabilityZone"`
+	ResourceCreationTime         *time.Time       `json:"resourceCreationTime"`
+	ConfigurationItemCaptureTime *time.Time       `json:"configurationItemCaptureTime"`
+	ConfigurationItemStatus      *string          `json:"configurationItemStatus"`
+	Tags                         []awsResourceTag `json:"tags"`
+	Configuration                interface{}      `json:"configuration"`
+	SupplementaryConfiguration   interface{}      `json:"supplementaryConfiguration"`
+}
+
+type awsResourceTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+//// TABLE DEFINITION
+
+func tableAwsResource(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_resource",
+		Description: "AWS Resource",
+		List: &plugin.ListConfig{
+			Hydrate: listAwsResources,
+			Tags:    map[string]string{"service": "config", "action": "SelectResourceConfig"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "resource_type", Require: plugin.Optional},
+				{Name: "resource_id", Require: plugin.Optional},
+				{Name: "resource_name", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				// NoSuchConfigurationRecorderException - AWS Config is not set up in the region.
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"NoSuchConfigurationRecorderException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(configservicev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "arn",
+				Description: "The Amazon Resource Name (ARN) of the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_type",
+				Description: "The type of the resource (for example, AWS::EC2::Instance).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_id",
+				Description: "The ID of the resource (for example, i-0123456789abcdef0).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_name",
+				Description: "The custom name of the resource, if available.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "availability_zone",
+				Description: "The Availability Zone associated with the resource.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "resource_creation_time",
+				Description: "The time stamp when the resource was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "configuration_item_capture_time",
+				Description: "The time when the current configuration of the resource was recorded.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "configuration_item_status",
+				Description: "The configuration item status. Possible values are OK, ResourceDiscovered, ResourceNotRecorded, ResourceDeleted and ResourceDeletedNotRecorded.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "configuration",
+				Description: "The configuration of the resource, as recorded by AWS Config.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "supplementary_configuration",
+				Description: "Configuration attributes that AWS Config returns for certain resource types to supplement the configuration, e.g. the bucket policy of an S3 bucket.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags_src",
+				Description: "A list of tags attached to the resource.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Tags"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.From(awsResourceTitle),
+			},
+			{
+				Name:        "tags",
+				Description: resourceInterfaceDescription("tags"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(awsResourceTurbotTags),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("Arn").Transform(arnToAkas),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listAwsResources(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	// Create session
+	svc, err := ConfigClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_resource.listAwsResources", "get_client_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int32(100)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	input := &configservice.SelectResourceConfigInput{
+		Expression: aws.String(buildAwsResourceExpression(d)),
+		Limit:      maxLimit,
+	}
+
+	paginator := configservice.NewSelectResourceConfigPaginator(svc, input, func(o *configservice.SelectResourceConfigPaginatorOptions) {
+		o.Limit = maxLimit
+		o.StopOnDuplicateToken = true
+	})
+
+	// List call
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_resource.listAwsResources", "api_error", err)
+			return nil, err
+		}
+
+		for _, result := range output.Results {
+			var item awsResource
+			if err := json.Unmarshal([]byte(result), &item); err != nil {
+				plugin.Logger(ctx).Error("aws_resource.listAwsResources", "unmarshal_error", err)
+				return nil, err
+			}
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func awsResourceTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	item := d.HydrateItem.(awsResource)
+	if item.ResourceName != nil && *item.ResourceName != "" {
+		return item.ResourceName, nil
+	}
+	return item.ResourceId, nil
+}
+
+func awsResourceTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	item := d.HydrateItem.(awsResource)
+	if len(item.Tags) == 0 {
+		return nil, nil
+	}
+
+	turbotTagsMap := map[string]string{}
+	for _, i := range item.Tags {
+		turbotTagsMap[i.Key] = i.Value
+	}
+
+	return turbotTagsMap, nil
+}
+
+//// UTILITY FUNCTION
+
+// Build the advanced query expression, pushing equality quals down as
+// conditions. Values containing a quote cannot be expressed in the query
+// language and are left for Postgres to filter.
+func buildAwsResourceExpression(d *plugin.QueryData) string {
+	var conditions []string
+	for _, qual := range []struct{ column, property string }{
+		{"resource_type", "resourceType"},
+		{"resource_id", "resourceId"},
+		{"resource_name", "resourceName"},
+	} {
+		value := d.EqualsQualString(qual.column)
+		if value == "" || strings.Contains(value, "'") {
+			continue
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = '%s'", qual.property, value))
+	}
+
+	expression := "SELECT " + awsResourceSelectProperties
+	if len(conditions) > 0 {
+		expression += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return expression
+}
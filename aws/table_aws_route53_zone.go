@@ -48,6 +48,11 @@ func tableAwsRoute53Zone(_ context.Context) *plugin.Table {
 				Func: getHostedZoneDNSSEC,
 				Tags: map[string]string{"service": "route53", "action": "GetDNSSEC"},
 			},
+			{
+				Func:    getHostedZoneParentDelegation,
+				Depends: []plugin.HydrateFunc{getHostedZone},
+				Tags:    map[string]string{"service": "route53", "action": "ListResourceRecordSets"},
+			},
 		},
 		Columns: awsGlobalRegionColumns([]*plugin.Column{
 			{
@@ -102,6 +107,13 @@ func tableAwsRoute53Zone(_ context.Context) *plugin.Table {
 				Hydrate:     getHostedZoneQueryLoggingConfigs,
 				Transform:   transform.FromField("QueryLoggingConfigs"),
 			},
+			{
+				Name:        "query_logging_enabled",
+				Description: "True if DNS query logging is configured for the hosted zone.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getHostedZoneQueryLoggingConfigs,
+				Transform:   transform.FromField("QueryLoggingConfigs").Transform(route53QueryLoggingEnabled),
+			},
 			{
 				Name:        "dnssec_key_signing_keys",
 				Description: "The key-signing keys (KSKs) in AWS account.",
@@ -116,6 +128,55 @@ func tableAwsRoute53Zone(_ context.Context) *plugin.Table {
 				Hydrate:     getHostedZoneDNSSEC,
 				Transform:   transform.FromField("Status"),
 			},
+			{
+				Name:        "dnssec_signing_status",
+				Description: "The DNSSEC signing status of the hosted zone. Valid values are SIGNING, NOT_SIGNING, DELETING, ACTION_NEEDED and INTERNAL_FAILURE.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getHostedZoneDNSSEC,
+				Transform:   transform.FromField("Status.ServeSignature"),
+			},
+			{
+				Name:        "dnssec_active_key_signing_key_count",
+				Description: "The number of key-signing keys (KSKs) in the ACTIVE state.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getHostedZoneDNSSEC,
+				Transform:   transform.FromField("KeySigningKeys").Transform(route53ActiveKeySigningKeyCount),
+			},
+			{
+				Name:        "delegation_set_name_servers",
+				Description: "The name servers Route 53 assigned to the hosted zone.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getHostedZone,
+				Transform:   transform.FromField("DelegationSet.NameServers"),
+			},
+			{
+				Name:        "parent_name_servers",
+				Description: "The name servers in the NS record set for the hosted zone in its parent. Null if the parent is not a hosted zone or registered domain in this account.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getHostedZoneParentDelegation,
+				Transform:   transform.FromField("NameServers"),
+			},
+			{
+				Name:        "parent_name_servers_source",
+				Description: "Where parent_name_servers was read from, hosted_zone for a parent hosted zone in this account, or registered_domain for a domain registered with Route 53.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getHostedZoneParentDelegation,
+				Transform:   transform.FromField("Source"),
+			},
+			{
+				Name:        "parent_zone_id",
+				Description: "The ID of the parent hosted zone the name servers were read from.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getHostedZoneParentDelegation,
+				Transform:   transform.FromField("ParentZoneId"),
+			},
+			{
+				Name:        "delegation_matches_parent",
+				Description: "True if the parent's name servers are the same as the hosted zone's delegation set. Null if the parent could not be found.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getHostedZoneParentDelegation,
+				Transform:   transform.FromField("Matches"),
+			},
 			{
 				Name:        "tags_src",
 				Description: resourceInterfaceDescription("tags"),
@@ -165,7 +226,8 @@ func tableAwsRoute53Zone(_ context.Context) *plugin.Table {
 
 type HostedZoneResult struct {
 	types.HostedZone
-	VPCs []types.VPC
+	DelegationSet *types.DelegationSet
+	VPCs          []types.VPC
 }
 
 //// LIST FUNCTION
@@ -260,8 +322,9 @@ func getHostedZone(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 	}
 
 	return HostedZoneResult{
-		HostedZone: *item.HostedZone,
-		VPCs:       item.VPCs,
+		HostedZone:    *item.HostedZone,
+		DelegationSet: item.DelegationSet,
+		VPCs:          item.VPCs,
 	}, nil
 }
 
@@ -400,6 +463,30 @@ func route53ZoneID(_ context.Context, d *transform.TransformData) (interface{},
 	return id, nil
 }
 
+func route53QueryLoggingEnabled(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.HydrateItem == nil {
+		return nil, nil
+	}
+	configs, _ := d.Value.([]types.QueryLoggingConfig)
+
+	return len(configs) > 0, nil
+}
+
+func route53ActiveKeySigningKeyCount(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	if d.HydrateItem == nil {
+		return nil, nil
+	}
+	keys, _ := d.Value.([]types.KeySigningKey)
+
+	count := 0
+	for _, key := range keys {
+		if aws.ToString(key.Status) == "ACTIVE" {
+			count++
+		}
+	}
+	return count, nil
+}
+
 func route53HostedZoneTurbotTags(ctx context.Context, d *transform.TransformData) (interface{}, error) {
 	tags := d.Value.([]types.Tag)
 
@@ -94,6 +94,10 @@ func listS3Account(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateDa
 func getAccountBucketPublicAccessBlock(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	s3Account := h.Item.(*awsCommonColumnData)
 
+	return doGetAccountBucketPublicAccessBlock(ctx, d, h, s3Account.AccountId)
+}
+
+func doGetAccountBucketPublicAccessBlock(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, accountId string) (*types.PublicAccessBlockConfiguration, error) {
 	// Unlike most services, S3 buckets are a global list. They can be retrieved
 	// from any single region. It's best to use the client region of the user
 	// (e.g. closest to them).
@@ -108,7 +112,7 @@ func getAccountBucketPublicAccessBlock(ctx context.Context, d *plugin.QueryData,
 	}
 
 	params := &s3control.GetPublicAccessBlockInput{
-		AccountId: &s3Account.AccountId,
+		AccountId: &accountId,
 	}
 
 	defaultAccessBlock := &types.PublicAccessBlockConfiguration{
@@ -133,7 +137,18 @@ func getAccountBucketPublicAccessBlock(ctx context.Context, d *plugin.QueryData,
 		return nil, err
 	}
 
-	return accessBlock.PublicAccessBlockConfiguration, nil
+	if accessBlock.PublicAccessBlockConfiguration == nil {
+		return defaultAccessBlock, nil
+	}
+
+	// Same fields as the bucket level configuration, which callers also use
+	config := accessBlock.PublicAccessBlockConfiguration
+	return &types.PublicAccessBlockConfiguration{
+		BlockPublicAcls:       config.BlockPublicAcls,
+		BlockPublicPolicy:     config.BlockPublicPolicy,
+		IgnorePublicAcls:      config.IgnorePublicAcls,
+		RestrictPublicBuckets: config.RestrictPublicBuckets,
+	}, nil
 }
 
 //// Transform Functions
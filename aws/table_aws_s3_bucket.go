@@ -10,9 +10,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go"
 
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
 )
 
 func tableAwsS3Bucket(_ context.Context) *plugin.Table {
@@ -71,6 +74,10 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 				Depends: []plugin.HydrateFunc{getBucketRegion},
 				Tags:    map[string]string{"service": "s3", "action": "GetBucketPolicy"},
 			},
+			{
+				Func:    getBucketPolicyAnalysis,
+				Depends: []plugin.HydrateFunc{getBucketPolicy, getBucketPublicAccessBlock},
+			},
 			{
 				Func:    getBucketReplication,
 				Depends: []plugin.HydrateFunc{getBucketRegion},
@@ -96,6 +103,10 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 				Depends: []plugin.HydrateFunc{getBucketRegion},
 				Tags:    map[string]string{"service": "s3", "action": "GetBucketOwnershipControls"},
 			},
+			{
+				Func:    getBucketAclPublicAccess,
+				Depends: []plugin.HydrateFunc{getBucketACL, getS3BucketObjectOwnershipControl, getBucketPublicAccessBlock},
+			},
 			{
 				Func:    getBucketWebsite,
 				Depends: []plugin.HydrateFunc{getBucketRegion},
@@ -218,6 +229,34 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 				Hydrate:     getS3BucketObjectOwnershipControl,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "object_ownership",
+				Description: "The object ownership setting of the bucket. Possible values are: BucketOwnerEnforced (ACLs are disabled), BucketOwnerPreferred or ObjectWriter. Null if the bucket has no ownership controls, in which case ACLs are enabled and the object writer owns each object.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getS3BucketObjectOwnershipControl,
+				Transform:   transform.From(s3BucketObjectOwnership),
+			},
+			{
+				Name:        "acls_disabled",
+				Description: "True if ACLs are disabled for the bucket and its objects, because object ownership is BucketOwnerEnforced. ACLs then no longer affect access.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBucketAclPublicAccess,
+				Transform:   transform.FromField("AclsDisabled"),
+			},
+			{
+				Name:        "acl_public_grants",
+				Description: "The grants in the bucket ACL to the AllUsers or AuthenticatedUsers groups, whether or not they are in effect.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBucketAclPublicAccess,
+				Transform:   transform.FromField("PublicGrants"),
+			},
+			{
+				Name:        "acl_allows_public_access",
+				Description: "True if the bucket ACL grants access to the AllUsers or AuthenticatedUsers groups and the grants are in effect, i.e. ACLs are not disabled and the bucket's public access block does not ignore public ACLs.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getBucketAclPublicAccess,
+				Transform:   transform.FromField("AllowsPublicAccess"),
+			},
 			{
 				Name:        "policy",
 				Description: "The resource IAM access document for the bucket.",
@@ -232,6 +271,27 @@ func tableAwsS3Bucket(_ context.Context) *plugin.Table {
 				Hydrate:     getBucketPolicy,
 				Transform:   transform.FromField("Policy").Transform(policyToCanonical),
 			},
+			{
+				Name:        "policy_ineffective_statement_ids",
+				Description: "The statements of the bucket policy that cannot allow or deny anything, because none of their actions exist, all of their principals are deleted users or roles, or none of their resources match the bucket. A Deny statement is not reported just because the policy has no matching Allow, as it still denies access allowed by other policies. Statements are identified by Sid, or by their position in the policy if they have none.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBucketPolicyAnalysis,
+				Transform:   transform.FromField("IneffectiveStatementIds"),
+			},
+			{
+				Name:        "policy_metrics",
+				Description: "The size and complexity of the bucket policy: the number of statements, distinct actions after expanding wildcards, statements using wildcard actions, services and condition keys, and the policy size in bytes against the 20 KB quota.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBucketPolicyAnalysis,
+				Transform:   transform.FromField("Metrics"),
+			},
+			{
+				Name:        "policy_evaluation",
+				Description: "Whether the bucket policy is public, and the principals it allows, classified as AWS accounts, services and federated identities. A statement for all principals is public unless a condition limits the principals or networks it applies to; negated conditions only exclude some, and are listed in ConditionExclusions. AccountClassifications marks each allowed account private if it owns the bucket or is one of the connection's trusted_accounts, service if it is an AWS owned account listed in aws_wellknown_public_account, or shared if neither. PrincipalSources lists the statements that allow each principal. Regional service principals, e.g. logs.us-east-1.amazonaws.com, are listed in their canonical form. Unique IDs left by deleted users and roles allow nothing and are listed in DeletedPrincipalIds. Statements that cannot allow anything, e.g. because their resources name another bucket, are not counted and are listed in IneffectiveStatementIds. A public policy is not in effect if Block Public Access restricts public buckets on the bucket or its account, so IsPublic is false and the settings are listed in PublicAccessOverriddenBy. Statements describes each evaluated Allow statement: WildcardResource is mandatory if it grants actions on all resources that cannot be scoped to specific resources, or lazy if some of them can, and ConditionKeys are the condition keys its actions support. A policy with an unconditional Deny of all actions to all principals allows nothing, so its evaluation is empty.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getBucketPolicyAnalysis,
+				Transform:   transform.FromField("Evaluation"),
+			},
 			{
 				Name:        "replication",
 				Description: "The replication configuration of a bucket.",
@@ -431,6 +491,48 @@ func getS3BucketObjectOwnershipControl(ctx context.Context, d *plugin.QueryData,
 	return conf.OwnershipControls, nil
 }
 
+// Grantee URIs of the groups that make an ACL grant public
+var s3PublicAclGroupUris = []string{
+	"http://acs.amazonaws.com/groups/global/AllUsers",
+	"http://acs.amazonaws.com/groups/global/AuthenticatedUsers",
+}
+
+type s3BucketAclPublicAccess struct {
+	AclsDisabled       bool
+	PublicGrants       []types.Grant
+	AllowsPublicAccess bool
+}
+
+// getBucketAclPublicAccess works out whether the bucket ACL makes the bucket
+// public. Public grants have no effect when ACLs are disabled by the
+// BucketOwnerEnforced object ownership setting, or ignored by the public
+// access block.
+func getBucketAclPublicAccess(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	result := s3BucketAclPublicAccess{PublicGrants: []types.Grant{}}
+
+	if ownershipControls, ok := h.HydrateResults["getS3BucketObjectOwnershipControl"].(*types.OwnershipControls); ok {
+		result.AclsDisabled = s3ObjectOwnership(ownershipControls) == string(types.ObjectOwnershipBucketOwnerEnforced)
+	}
+
+	if acl, ok := h.HydrateResults["getBucketACL"].(*map[string]any); ok && acl != nil {
+		grants, _ := (*acl)["Grants"].([]types.Grant)
+		for _, grant := range grants {
+			if grant.Grantee != nil && helpers.StringSliceContains(s3PublicAclGroupUris, aws.ToString(grant.Grantee.URI)) {
+				result.PublicGrants = append(result.PublicGrants, grant)
+			}
+		}
+	}
+
+	ignorePublicAcls := false
+	if accessBlock, ok := h.HydrateResults["getBucketPublicAccessBlock"].(*types.PublicAccessBlockConfiguration); ok && accessBlock != nil {
+		ignorePublicAcls = aws.ToBool(accessBlock.IgnorePublicAcls)
+	}
+
+	result.AllowsPublicAccess = len(result.PublicGrants) > 0 && !result.AclsDisabled && !ignorePublicAcls
+
+	return result, nil
+}
+
 func getBucketIsPublic(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	bucketName := h.Item.(types.Bucket).Name
 	bucketRegion := h.HydrateResults["getBucketRegion"].(string)
@@ -649,6 +751,79 @@ func getBucketPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrate
 	return bucketPolicy, nil
 }
 
+func getBucketPolicyAnalysis(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	bucketPolicy := h.HydrateResults["getBucketPolicy"].(*s3.GetBucketPolicyOutput)
+	if bucketPolicy.Policy == nil {
+		return nil, nil
+	}
+
+	policy, err := canonicalPolicy(*bucketPolicy.Policy)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "policy_error", err)
+		return nil, err
+	}
+
+	arn, err := getBucketARN(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	knownActions, err := getIamKnownActionsCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "iam_permissions_error", err)
+		return nil, err
+	}
+	expander, err := getIamActionExpanderCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "iam_permissions_error", err)
+		return nil, err
+	}
+	actionMetadata, err := getIamActionMetadataCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "iam_permissions_error", err)
+		return nil, err
+	}
+
+	// The bucket's own account and the configured trusted accounts are "self"
+	c, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "get_common_columns_error", err)
+		return nil, err
+	}
+	selfAccountIds := map[string]bool{c.(*awsCommonColumnData).AccountId: true}
+	for _, accountId := range GetConfig(d.Connection).TrustedAccounts {
+		selfAccountIds[accountId] = true
+	}
+
+	// RestrictPublicBuckets on the bucket or its account stops a public
+	// policy being in effect
+	accountAccessBlock, err := getS3AccountPublicAccessBlockCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketPolicyAnalysis", "account_public_access_block_error", err)
+		return nil, err
+	}
+	publicAccessBlock := policyeval.PublicAccessBlock{
+		AccountRestrictPublicBuckets: aws.ToBool(accountAccessBlock.(*types.PublicAccessBlockConfiguration).RestrictPublicBuckets),
+	}
+	if bucketAccessBlock, ok := h.HydrateResults["getBucketPublicAccessBlock"].(*types.PublicAccessBlockConfiguration); ok && bucketAccessBlock != nil {
+		publicAccessBlock.BucketRestrictPublicBuckets = aws.ToBool(bucketAccessBlock.RestrictPublicBuckets)
+	}
+
+	return iamPolicyAnalysis{
+		IneffectiveStatementIds: policyeval.IneffectiveStatementIds(policy.(Policy), arn.(string), knownActions.(policyeval.KnownActions)),
+		Metrics:                 policyeval.PolicyMetrics(policy.(Policy), len(*bucketPolicy.Policy), policyeval.S3BucketPolicySizeQuota, expander.(*policyeval.ActionExpander)),
+		Evaluation: policyeval.EvaluatePolicy(policy.(Policy), policyeval.EvaluatePolicyOptions{
+			ResourceArn:         arn.(string),
+			KnownActions:        knownActions.(policyeval.KnownActions),
+			PublicAccessBlock:   publicAccessBlock,
+			SelfAccountIds:      selfAccountIds,
+			WellKnownAccountIds: getWellKnownAccountIds(d),
+			ActionExpander:      expander.(*policyeval.ActionExpander),
+			ActionMetadata:      actionMetadata.(policyeval.ActionMetadata),
+		}),
+	}, nil
+}
+
 func getBucketReplication(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	bucketName := h.Item.(types.Bucket).Name
 	bucketRegion := h.HydrateResults["getBucketRegion"].(string)
@@ -689,8 +864,14 @@ func getBucketTagging(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 
 	params := &s3.GetBucketTaggingInput{Bucket: bucketName}
 
-	bucketTags, _ := svc.GetBucketTagging(ctx, params)
+	bucketTags, err := svc.GetBucketTagging(ctx, params)
 	if err != nil {
+		var a smithy.APIError
+		if errors.As(err, &a) {
+			if a.ErrorCode() == "NoSuchTagSet" {
+				return nil, nil
+			}
+		}
 		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketTagging", "api_error", err)
 		return nil, err
 	}
@@ -711,8 +892,14 @@ func getBucketWebsite(ctx context.Context, d *plugin.QueryData, h *plugin.Hydrat
 
 	params := &s3.GetBucketWebsiteInput{Bucket: bucketName}
 
-	bucketwebsites, _ := svc.GetBucketWebsite(ctx, params)
+	bucketwebsites, err := svc.GetBucketWebsite(ctx, params)
 	if err != nil {
+		var a smithy.APIError
+		if errors.As(err, &a) {
+			if a.ErrorCode() == "NoSuchWebsiteConfiguration" {
+				return nil, nil
+			}
+		}
 		plugin.Logger(ctx).Error("aws_s3_bucket.getBucketWebsite", "api_error", err)
 		return nil, err
 	}
@@ -765,6 +952,27 @@ func getObjectLockConfiguration(ctx context.Context, d *plugin.QueryData, h *plu
 
 //// TRANSFORM FUNCTIONS
 
+func s3BucketObjectOwnership(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	ownershipControls, ok := d.HydrateItem.(*types.OwnershipControls)
+	if !ok {
+		return nil, nil
+	}
+
+	if ownership := s3ObjectOwnership(ownershipControls); ownership != "" {
+		return ownership, nil
+	}
+	return nil, nil
+}
+
+// s3ObjectOwnership returns the object ownership setting from the bucket's
+// ownership controls, which have a single rule
+func s3ObjectOwnership(ownershipControls *types.OwnershipControls) string {
+	if ownershipControls == nil || len(ownershipControls.Rules) == 0 {
+		return ""
+	}
+	return string(ownershipControls.Rules[0].ObjectOwnership)
+}
+
 func handleS3TagsToTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
 	tags := d.Value.([]types.Tag)
 
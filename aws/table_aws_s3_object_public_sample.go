@@ -0,0 +1,376 @@
+package aws
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+const (
+	s3ObjectPublicSampleDefaultSize = 50
+	// A single ListObjectsV2 page
+	s3ObjectPublicSampleMaxSize = 1000
+	// Number of public object keys returned as examples
+	s3ObjectPublicSampleExampleKeys = 10
+)
+
+// Values of the public_exposure column
+const (
+	s3PublicExposureBucket  = "bucket"
+	s3PublicExposureObjects = "objects"
+	s3PublicExposureNone    = "none"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsS3ObjectPublicSample(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_s3_object_public_sample",
+		Description: "AWS S3 Object Public Sample, an estimate of the objects in each bucket made public by their object ACL. Makes a GetObjectAcl call per sampled object, so filter on bucket_name where possible.",
+		List: &plugin.ListConfig{
+			Hydrate: listS3ObjectPublicSampleBuckets,
+			Tags:    map[string]string{"service": "s3", "action": "ListBucket"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "bucket_name", Require: plugin.Optional},
+				{Name: "sample_size", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getBucketRegion,
+				Tags: map[string]string{"service": "s3", "action": "HeadBucket"},
+			},
+			{
+				Func:    getBucketIsPublic,
+				Depends: []plugin.HydrateFunc{getBucketRegion},
+				Tags:    map[string]string{"service": "s3", "action": "GetBucketPolicyStatus"},
+			},
+			{
+				Func:    getBucketPublicAccessBlock,
+				Depends: []plugin.HydrateFunc{getBucketRegion},
+				Tags:    map[string]string{"service": "s3", "action": "GetPublicAccessBlock"},
+			},
+			{
+				Func:    getBucketACL,
+				Depends: []plugin.HydrateFunc{getBucketRegion},
+				Tags:    map[string]string{"service": "s3", "action": "GetBucketAcl"},
+			},
+			{
+				Func:    getS3BucketObjectOwnershipControl,
+				Depends: []plugin.HydrateFunc{getBucketRegion},
+				Tags:    map[string]string{"service": "s3", "action": "GetBucketOwnershipControls"},
+			},
+			{
+				Func:    getBucketAclPublicAccess,
+				Depends: []plugin.HydrateFunc{getBucketACL, getS3BucketObjectOwnershipControl, getBucketPublicAccessBlock},
+			},
+			{
+				Func:    getS3ObjectPublicSample,
+				Depends: []plugin.HydrateFunc{getBucketRegion, getBucketIsPublic, getBucketPublicAccessBlock, getBucketAclPublicAccess},
+				Tags:    map[string]string{"service": "s3", "action": "GetObjectAcl"},
+				// The bucket may be deleted after it is listed, or its objects
+				// may not be listable
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"NoSuchBucket", "AccessDenied"}),
+				},
+			},
+		},
+		Columns: awsAccountColumns([]*plugin.Column{
+			{
+				Name:        "bucket_name",
+				Description: "The name of the bucket.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "sample_size",
+				Description: "The maximum number of objects sampled from the bucket, 50 by default and at most 1000. Set it in the where clause.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("SampleSize"),
+			},
+			{
+				Name:        "public_exposure",
+				Description: "How the bucket is exposed to the public. Possible values are: bucket (the bucket policy or ACL makes the bucket public), objects (only the ACLs of sampled objects make them public) and none.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("PublicExposure"),
+			},
+			{
+				Name:        "sampled_object_count",
+				Description: "The number of objects sampled, the first objects of the bucket in key order.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("SampledObjectCount"),
+			},
+			{
+				Name:        "unreadable_acl_count",
+				Description: "The number of sampled objects whose ACL could not be read, because access was denied or the object was deleted while sampling. They are not counted as public.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("UnreadableAclCount"),
+			},
+			{
+				Name:        "public_acl_object_count",
+				Description: "The number of sampled objects whose ACL grants access to all users or all authenticated AWS users, whether or not the grant is in effect.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("PublicAclObjectCount"),
+			},
+			{
+				Name:        "public_object_count",
+				Description: "The number of sampled objects made public by their ACL. Public grants have no effect when ACLs are disabled for the bucket, or ignored by the bucket or account public access block.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("PublicObjectCount"),
+			},
+			{
+				Name:        "public_object_percent",
+				Description: "The percentage of sampled objects made public by their ACL, an estimate for the whole bucket. Objects whose ACL could not be read are left out.",
+				Type:        proto.ColumnType_DOUBLE,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("PublicObjectPercent"),
+			},
+			{
+				Name:        "example_public_keys",
+				Description: "The keys of up to 10 sampled objects made public by their ACL.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("ExamplePublicKeys"),
+			},
+			{
+				Name:        "bucket_has_more_objects",
+				Description: "True if the bucket has more objects than were sampled.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("BucketHasMoreObjects"),
+			},
+			{
+				Name:        "bucket_policy_is_public",
+				Description: "True if the bucket policy makes the bucket public and public bucket policies are not restricted by the bucket or account public access block.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("BucketPolicyIsPublic"),
+			},
+			{
+				Name:        "bucket_acl_allows_public_access",
+				Description: "True if the bucket ACL makes the bucket public, and the grant is not disabled or ignored.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("BucketAclAllowsPublicAccess"),
+			},
+			{
+				Name:        "acls_disabled",
+				Description: "True if ACLs are disabled for the bucket by the BucketOwnerEnforced object ownership setting. Object ACLs are then not checked.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("AclsDisabled"),
+			},
+			{
+				Name:        "ignore_public_acls",
+				Description: "True if public ACLs are ignored by the bucket or account public access block.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getS3ObjectPublicSample,
+				Transform:   transform.FromField("IgnorePublicAcls"),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Name"),
+			},
+			{
+				Name:        "region",
+				Description: "The AWS Region in which the bucket is located.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getBucketRegion,
+				Transform:   transform.FromValue(),
+			},
+		}),
+	}
+}
+
+type s3ObjectPublicSample struct {
+	SampleSize                  int
+	PublicExposure              string
+	SampledObjectCount          int
+	UnreadableAclCount          int
+	PublicAclObjectCount        int
+	PublicObjectCount           int
+	PublicObjectPercent         float64
+	ExamplePublicKeys           []string
+	BucketHasMoreObjects        bool
+	BucketPolicyIsPublic        bool
+	BucketAclAllowsPublicAccess bool
+	AclsDisabled                bool
+	IgnorePublicAcls            bool
+}
+
+//// LIST FUNCTION
+
+func listS3ObjectPublicSampleBuckets(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Avoid listing every bucket, which would sample every bucket before the
+	// rows are filtered
+	if bucketName := d.EqualsQualString("bucket_name"); bucketName != "" {
+		d.StreamListItem(ctx, types.Bucket{Name: aws.String(bucketName)})
+		return nil, nil
+	}
+
+	return listS3Buckets(ctx, d, h)
+}
+
+//// HYDRATE FUNCTIONS
+
+// Account level public access block settings apply to every bucket, so are
+// only looked up once per connection
+var getS3AccountPublicAccessBlockCached = plugin.HydrateFunc(getS3AccountPublicAccessBlockUncached).Memoize()
+
+func getS3AccountPublicAccessBlockUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	c, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+
+	return doGetAccountBucketPublicAccessBlock(ctx, d, h, c.(*awsCommonColumnData).AccountId)
+}
+
+// getS3ObjectPublicSample checks the ACLs of the first objects in the bucket,
+// and combines them with the bucket level settings that decide whether public
+// grants are in effect
+func getS3ObjectPublicSample(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	bucketName := h.Item.(types.Bucket).Name
+	bucketRegion := h.HydrateResults["getBucketRegion"].(string)
+
+	// The column returns the requested size, so the row matches the qual, but
+	// at most one page of objects is sampled
+	sampleSize := s3ObjectPublicSampleDefaultSize
+	if d.EqualsQuals["sample_size"] != nil {
+		sampleSize = int(d.EqualsQuals["sample_size"].GetInt64Value())
+	}
+	maxKeys := sampleSize
+	if maxKeys > s3ObjectPublicSampleMaxSize {
+		maxKeys = s3ObjectPublicSampleMaxSize
+	}
+
+	accountAccessBlock, err := getS3AccountPublicAccessBlockCached(ctx, d, h)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_s3_object_public_sample.getS3ObjectPublicSample", "account_public_access_block_error", err)
+		return nil, err
+	}
+
+	sample := s3ObjectPublicSample{
+		SampleSize:        sampleSize,
+		ExamplePublicKeys: []string{},
+	}
+
+	bucketAccessBlock, _ := h.HydrateResults["getBucketPublicAccessBlock"].(*types.PublicAccessBlockConfiguration)
+	restrictPublicBuckets := false
+	for _, accessBlock := range []*types.PublicAccessBlockConfiguration{accountAccessBlock.(*types.PublicAccessBlockConfiguration), bucketAccessBlock} {
+		if accessBlock != nil {
+			sample.IgnorePublicAcls = sample.IgnorePublicAcls || aws.ToBool(accessBlock.IgnorePublicAcls)
+			restrictPublicBuckets = restrictPublicBuckets || aws.ToBool(accessBlock.RestrictPublicBuckets)
+		}
+	}
+
+	if policyStatus, ok := h.HydrateResults["getBucketIsPublic"].(*s3.GetBucketPolicyStatusOutput); ok && policyStatus.PolicyStatus != nil {
+		sample.BucketPolicyIsPublic = aws.ToBool(policyStatus.PolicyStatus.IsPublic) && !restrictPublicBuckets
+	}
+
+	aclPublicAccess, _ := h.HydrateResults["getBucketAclPublicAccess"].(s3BucketAclPublicAccess)
+	sample.AclsDisabled = aclPublicAccess.AclsDisabled
+	sample.BucketAclAllowsPublicAccess = aclPublicAccess.AllowsPublicAccess && !sample.IgnorePublicAcls
+
+	if maxKeys > 0 {
+		// Create client
+		svc, err := S3Client(ctx, d, bucketRegion)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_s3_object_public_sample.getS3ObjectPublicSample", "client_error", err)
+			return nil, err
+		}
+
+		objects, err := svc.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+			Bucket:  bucketName,
+			MaxKeys: aws.Int32(int32(maxKeys)),
+		})
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_s3_object_public_sample.getS3ObjectPublicSample", "list_objects_error", err)
+			return nil, err
+		}
+		sample.BucketHasMoreObjects = aws.ToBool(objects.IsTruncated)
+
+		for _, object := range objects.Contents {
+			sample.SampledObjectCount++
+
+			// Object ACLs have no effect when ACLs are disabled, and
+			// GetObjectAcl is not supported by Amazon S3 on Outposts
+			if sample.AclsDisabled || isOutpostObject(string(object.StorageClass)) {
+				continue
+			}
+
+			objectAcl, err := svc.GetObjectAcl(ctx, &s3.GetObjectAclInput{
+				Bucket: bucketName,
+				Key:    object.Key,
+			})
+			if err != nil {
+				// Objects owned by another account, or deleted since they were
+				// listed, do not stop the rest of the sample being checked
+				var ae smithy.APIError
+				if errors.As(err, &ae) && (ae.ErrorCode() == "AccessDenied" || ae.ErrorCode() == "NoSuchKey") {
+					sample.UnreadableAclCount++
+					continue
+				}
+				plugin.Logger(ctx).Error("aws_s3_object_public_sample.getS3ObjectPublicSample", "get_object_acl_error", err, "key", aws.ToString(object.Key))
+				return nil, err
+			}
+
+			if !s3GrantsArePublic(objectAcl.Grants) {
+				continue
+			}
+			sample.PublicAclObjectCount++
+			if sample.IgnorePublicAcls {
+				continue
+			}
+			sample.PublicObjectCount++
+			if len(sample.ExamplePublicKeys) < s3ObjectPublicSampleExampleKeys {
+				sample.ExamplePublicKeys = append(sample.ExamplePublicKeys, aws.ToString(object.Key))
+			}
+		}
+	}
+
+	if readable := sample.SampledObjectCount - sample.UnreadableAclCount; readable > 0 {
+		sample.PublicObjectPercent = float64(sample.PublicObjectCount) * 100 / float64(readable)
+	}
+
+	switch {
+	case sample.BucketPolicyIsPublic || sample.BucketAclAllowsPublicAccess:
+		sample.PublicExposure = s3PublicExposureBucket
+	case sample.PublicObjectCount > 0:
+		sample.PublicExposure = s3PublicExposureObjects
+	default:
+		sample.PublicExposure = s3PublicExposureNone
+	}
+
+	return sample, nil
+}
+
+// s3GrantsArePublic returns true if any of the ACL grants is to all users or
+// all authenticated AWS users
+func s3GrantsArePublic(grants []types.Grant) bool {
+	for _, grant := range grants {
+		if grant.Grantee != nil && helpers.StringSliceContains(s3PublicAclGroupUris, aws.ToString(grant.Grantee.URI)) {
+			return true
+		}
+	}
+	return false
+}
@@ -33,19 +33,19 @@ func tableAwsSecurityHubFinding(_ context.Context) *plugin.Table {
 			Hydrate: listSecurityHubFindings,
 			Tags:    map[string]string{"service": "securityhub", "action": "GetFindings"},
 			KeyColumns: plugin.KeyColumnSlice{
-				{Name: "company_name", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "compliance_status", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+				{Name: "company_name", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "compliance_status", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
 				{Name: "confidence", Require: plugin.Optional, Operators: []string{"=", ">=", "<="}},
 				{Name: "criticality", Require: plugin.Optional, Operators: []string{"=", ">=", "<="}},
-				{Name: "generator_id", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "product_arn", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "product_name", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "record_state", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "title", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "verification_state", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "workflow_state", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "workflow_status", Require: plugin.Optional, Operators: []string{"=", "<>"}},
-				{Name: "source_account_id", Require: plugin.Optional, Operators: []string{"=", "<>"}},
+				{Name: "generator_id", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "product_arn", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "product_name", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "record_state", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "title", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "verification_state", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "workflow_state", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "workflow_status", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
+				{Name: "source_account_id", Require: plugin.Optional, Operators: []string{"=", "<>", "~~"}},
 			},
 			IgnoreConfig: &plugin.IgnoreConfig{
 				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"InvalidAccessException"}),
@@ -298,6 +298,7 @@ func listSecurityHubFindings(ctx context.Context, d *plugin.QueryData, _ *plugin
 	findingsFilter := buildListFindingsParam(d.Quals)
 	if findingsFilter != nil {
 		input.Filters = findingsFilter
+		plugin.Logger(ctx).Debug("aws_securityhub_finding.listSecurityHubFindings", "filters_pushed", findingsFilter)
 	}
 
 	// List call
@@ -405,6 +406,19 @@ func buildListFindingsParam(quals plugin.KeyColumnQualMap) *types.AwsSecurityFin
 				strFilter.Comparison = "NOT_EQUALS"
 			case "=":
 				strFilter.Comparison = "EQUALS"
+			case "~~":
+				// Only LIKE 'abc%' and LIKE '%abc%' can be expressed as filters. Security Hub
+				// restricts combining CONTAINS and PREFIX with other comparisons on the
+				// same field, so the pattern is only pushed down when used on its own.
+				substring, prefix, ok := likePatternToSubstring(value)
+				if !ok || len(quals[s].Quals) > 1 {
+					continue
+				}
+				value = substring
+				strFilter.Comparison = "CONTAINS"
+				if prefix {
+					strFilter.Comparison = "PREFIX"
+				}
 			}
 
 			switch s {
@@ -0,0 +1,184 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/securitylake"
+	"github.com/aws/aws-sdk-go-v2/service/securitylake/types"
+
+	securitylakev1 "github.com/aws/aws-sdk-go/service/securitylake"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// securityLakeLogSource is a single AWS or custom log source enabled for an
+// account, flattened from the LogSource union returned by ListLogSources
+type securityLakeLogSource struct {
+	SourceAccountId  *string
+	SourceType       string
+	SourceName       *string
+	SourceVersion    *string
+	CrawlerArn       *string
+	DatabaseArn      *string
+	TableArn         *string
+	ProviderLocation *string
+	ProviderRoleArn  *string
+}
+
+//// TABLE DEFINITION
+
+func tableAwsSecurityLakeLogSource(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_securitylake_log_source",
+		Description: "AWS Security Lake Log Source",
+		List: &plugin.ListConfig{
+			Hydrate: listSecurityLakeLogSources,
+			Tags:    map[string]string{"service": "securitylake", "action": "ListLogSources"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "source_account_id", Require: plugin.Optional},
+			},
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"ResourceNotFoundException"}),
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(securitylakev1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "source_account_id",
+				Description: "The ID of the account the log source is enabled for.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_type",
+				Description: "The type of the log source. Possible values are: aws (a natively supported Amazon Web Services service) or custom (a third-party or custom source).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_name",
+				Description: "The name of the log source, e.g. CLOUD_TRAIL_MGMT, VPC_FLOW or SH_FINDINGS for AWS log sources.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_version",
+				Description: "The version of the log source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "crawler_arn",
+				Description: "The ARN of the Glue crawler for a custom log source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "database_arn",
+				Description: "The ARN of the Glue database where results are written for a custom log source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_arn",
+				Description: "The ARN of the Glue table for a custom log source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "provider_location",
+				Description: "The location of the partition in the Security Lake S3 bucket for a custom log source.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "provider_role_arn",
+				Description: "The ARN of the IAM role used by the entity putting logs into the custom log source partition.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("SourceName"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listSecurityLakeLogSources(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	// Create Client
+	svc, err := SecurityLakeClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_securitylake_log_source.listSecurityLakeLogSources", "client_error", err)
+		return nil, err
+	}
+	if svc == nil {
+		// Unsupported region check
+		return nil, nil
+	}
+
+	// ListLogSources returns the sources of every region of the data lake, so
+	// restrict it to the region being queried
+	input := &securitylake.ListLogSourcesInput{
+		MaxResults: aws.Int32(100),
+		Regions:    []string{region},
+	}
+	if d.EqualsQualString("source_account_id") != "" {
+		input.Accounts = []string{d.EqualsQualString("source_account_id")}
+	}
+
+	paginator := securitylake.NewListLogSourcesPaginator(svc, input, func(o *securitylake.ListLogSourcesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_securitylake_log_source.listSecurityLakeLogSources", "api_error", err)
+			return nil, err
+		}
+
+		for _, logSource := range output.Sources {
+			for _, source := range logSource.Sources {
+				item := securityLakeLogSource{
+					SourceAccountId: logSource.Account,
+				}
+				switch s := source.(type) {
+				case *types.LogSourceResourceMemberAwsLogSource:
+					item.SourceType = "aws"
+					item.SourceName = aws.String(string(s.Value.SourceName))
+					item.SourceVersion = s.Value.SourceVersion
+				case *types.LogSourceResourceMemberCustomLogSource:
+					item.SourceType = "custom"
+					item.SourceName = s.Value.SourceName
+					item.SourceVersion = s.Value.SourceVersion
+					if s.Value.Attributes != nil {
+						item.CrawlerArn = s.Value.Attributes.CrawlerArn
+						item.DatabaseArn = s.Value.Attributes.DatabaseArn
+						item.TableArn = s.Value.Attributes.TableArn
+					}
+					if s.Value.Provider != nil {
+						item.ProviderLocation = s.Value.Provider.Location
+						item.ProviderRoleArn = s.Value.Provider.RoleArn
+					}
+				default:
+					continue
+				}
+
+				d.StreamListItem(ctx, item)
+
+				// Context may get cancelled due to manual cancellation or if the limit has been reached
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
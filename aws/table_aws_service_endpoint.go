@@ -0,0 +1,402 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go/aws/endpoints"
+
+	"github.com/turbot/go-kit/helpers"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
+)
+
+// Each probe step gives up after this long, so an endpoint that is blackholed
+// (e.g. a private subnet with no route or VPC endpoint) shows up as a timeout
+// rather than a hang
+const serviceEndpointProbeTimeout = 5 * time.Second
+
+// Services probed when no service_id is given. These are called by most
+// queries, so are the first to hang when the network is misconfigured.
+var serviceEndpointDefaultServiceIDs = []string{"sts", "ec2", "iam", "s3", "logs"}
+
+//// TABLE DEFINITION
+
+func tableAwsServiceEndpoint(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_service_endpoint",
+		Description: "AWS Service Endpoint",
+		List: &plugin.ListConfig{
+			Hydrate: listServiceEndpoints,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "service_id", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: serviceEndpointRegionMatrix,
+		// The standard account and partition columns are not used, as they need
+		// a working connection to STS, which is what this table is used to debug
+		Columns: []*plugin.Column{
+			{
+				Name:        "service_id",
+				Description: "The endpoint ID of the service, e.g. ec2 or sts.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "region",
+				Description: "The AWS region the endpoint serves.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "endpoint",
+				Description: "The URL of the endpoint, as resolved by the AWS SDK or set by endpoint_url in the connection config.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host",
+				Description: "The host name of the endpoint.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "proxy_url",
+				Description: "The proxy used for requests to the endpoint, from the HTTPS_PROXY and NO_PROXY environment variables. When set, the TCP and TLS probes go through a CONNECT tunnel to the proxy, as the AWS SDK does.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "proxy_connect_status",
+				Description: "The status line of the proxy's response to the CONNECT request, e.g. 200 Connection established or 407 Proxy Authentication Required. Null if no proxy is used.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "dns_resolved",
+				Description: "True if the host name resolved to at least one address. When a proxy is used, the proxy resolves the host name, so the probe continues even if it does not resolve locally.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "resolved_addresses",
+				Description: "The addresses the host name resolved to.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "resolves_to_private_address",
+				Description: "True if every resolved address is private, which is the case when the endpoint is reached through an interface VPC endpoint with private DNS.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "dns_latency_ms",
+				Description: "Time taken to resolve the host name, in milliseconds.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "tcp_connected",
+				Description: "True if a TCP connection was made to the endpoint port, or, when a proxy is used, the proxy opened a tunnel to it.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "connect_latency_ms",
+				Description: "Time taken to make the TCP connection, including the CONNECT request when a proxy is used, in milliseconds.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "tls_handshake",
+				Description: "True if the TLS handshake completed. Always false for http endpoints.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "tls_version",
+				Description: "The TLS version negotiated with the endpoint.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tls_latency_ms",
+				Description: "Time taken to complete the TLS handshake, in milliseconds.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "error",
+				Description: "The error from the first probe step that failed, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Error").NullIfZero(),
+			},
+		},
+	}
+}
+
+type serviceEndpointProbe struct {
+	ServiceID                string
+	Region                   string
+	Endpoint                 string
+	Host                     string
+	ProxyUrl                 *string
+	ProxyConnectStatus       *string
+	DnsResolved              bool
+	ResolvedAddresses        []string
+	ResolvesToPrivateAddress *bool
+	DnsLatencyMs             *int64
+	TcpConnected             bool
+	ConnectLatencyMs         *int64
+	TlsHandshake             bool
+	TlsVersion               *string
+	TlsLatencyMs             *int64
+	Error                    string
+}
+
+//// LIST FUNCTION
+
+func listServiceEndpoints(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	serviceIDs := serviceEndpointDefaultServiceIDs
+	if serviceID := d.EqualsQualString("service_id"); serviceID != "" {
+		serviceIDs = []string{serviceID}
+	}
+
+	for _, serviceID := range serviceIDs {
+		d.StreamListItem(ctx, probeServiceEndpoint(ctx, d, serviceID, region))
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+// serviceEndpointRegionMatrix returns the regions in the connection config,
+// expanding wildcards against the regions known to the plugin. Unlike
+// SupportedRegionMatrix, no API calls are made, so the table works even when
+// the AWS endpoints are unreachable.
+func serviceEndpointRegionMatrix(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	awsSpcConfig := GetConfig(d.Connection)
+
+	var regions []string
+	if awsSpcConfig.Regions == nil {
+		region, err := getDefaultRegionFromConfig(ctx, d, nil)
+		if err != nil {
+			plugin.Logger(ctx).Error("serviceEndpointRegionMatrix", "connection_name", d.Connection.Name, "default_region_error", err)
+			return nil
+		}
+		regions = []string{region}
+	} else {
+		var allRegions []string
+		allRegions = append(allRegions, awsCommercialRegions()...)
+		allRegions = append(allRegions, awsUsGovRegions()...)
+		allRegions = append(allRegions, awsChinaRegions()...)
+		allRegions = append(allRegions, awsUsIsoRegions()...)
+		allRegions = append(allRegions, awsUsIsobRegions()...)
+
+		for _, pattern := range awsSpcConfig.Regions {
+			if !strings.ContainsAny(pattern, "*?") && !helpers.StringSliceContains(allRegions, pattern) {
+				// Not a known region, but probe it anyway as it may be newer than
+				// the plugin's list
+				regions = append(regions, pattern)
+				continue
+			}
+			for _, region := range allRegions {
+				if wildcard.Match(pattern, region) {
+					regions = append(regions, region)
+				}
+			}
+		}
+		regions = helpers.StringSliceDistinct(regions)
+	}
+
+	matrix := make([]map[string]interface{}, len(regions))
+	for i, region := range regions {
+		matrix[i] = map[string]interface{}{matrixKeyRegion: region}
+	}
+	return matrix
+}
+
+// probeServiceEndpoint resolves the endpoint of the service in the region and
+// probes it, stopping at the first step that fails
+func probeServiceEndpoint(ctx context.Context, d *plugin.QueryData, serviceID string, region string) serviceEndpointProbe {
+	probe := serviceEndpointProbe{ServiceID: serviceID, Region: region}
+
+	endpoint, err := resolveServiceEndpoint(d, serviceID, region)
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.Endpoint = endpoint.String()
+	probe.Host = endpoint.Hostname()
+	port := endpoint.Port()
+	if port == "" {
+		port = "443"
+		if endpoint.Scheme == "http" {
+			port = "80"
+		}
+	}
+
+	proxyUrl, err := http.ProxyFromEnvironment(&http.Request{URL: endpoint})
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	if proxyUrl != nil {
+		probe.ProxyUrl = aws.String(proxyUrl.String())
+	}
+
+	// DNS
+	dnsCtx, cancel := context.WithTimeout(ctx, serviceEndpointProbeTimeout)
+	defer cancel()
+	start := time.Now()
+	addresses, err := net.DefaultResolver.LookupHost(dnsCtx, probe.Host)
+	probe.DnsLatencyMs = aws.Int64(time.Since(start).Milliseconds())
+	// The proxy resolves the host name, which may not resolve locally
+	if err != nil && proxyUrl == nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.DnsResolved = len(addresses) > 0
+	probe.ResolvedAddresses = addresses
+	private := len(addresses) > 0
+	for _, address := range addresses {
+		if ip := net.ParseIP(address); ip == nil || !ip.IsPrivate() {
+			private = false
+		}
+	}
+	probe.ResolvesToPrivateAddress = &private
+
+	// TCP connection, through a CONNECT tunnel if there is a proxy
+	address := net.JoinHostPort(probe.Host, port)
+	start = time.Now()
+	var conn net.Conn
+	if proxyUrl == nil {
+		dialer := &net.Dialer{Timeout: serviceEndpointProbeTimeout}
+		conn, err = dialer.DialContext(ctx, "tcp", address)
+	} else {
+		var status string
+		conn, status, err = dialServiceEndpointProxy(ctx, proxyUrl, address)
+		if status != "" {
+			probe.ProxyConnectStatus = aws.String(status)
+		}
+	}
+	probe.ConnectLatencyMs = aws.Int64(time.Since(start).Milliseconds())
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	defer conn.Close()
+	probe.TcpConnected = true
+
+	// TLS handshake, unless endpoint_url is a plain http URL
+	if endpoint.Scheme != "https" {
+		return probe
+	}
+	tlsCtx, cancel := context.WithTimeout(ctx, serviceEndpointProbeTimeout)
+	defer cancel()
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: probe.Host})
+	start = time.Now()
+	err = tlsConn.HandshakeContext(tlsCtx)
+	probe.TlsLatencyMs = aws.Int64(time.Since(start).Milliseconds())
+	if err != nil {
+		probe.Error = err.Error()
+		return probe
+	}
+	probe.TlsHandshake = true
+	probe.TlsVersion = aws.String(tls.VersionName(tlsConn.ConnectionState().Version))
+
+	return probe
+}
+
+// dialServiceEndpointProxy opens a tunnel to address through an http or https
+// proxy with a CONNECT request, and returns it with the status line of the
+// proxy's response
+func dialServiceEndpointProxy(ctx context.Context, proxyUrl *url.URL, address string) (net.Conn, string, error) {
+	proxyPort := proxyUrl.Port()
+	switch proxyUrl.Scheme {
+	case "http":
+		if proxyPort == "" {
+			proxyPort = "80"
+		}
+	case "https":
+		if proxyPort == "" {
+			proxyPort = "443"
+		}
+	default:
+		return nil, "", fmt.Errorf("proxy scheme %q is not supported by the probe", proxyUrl.Scheme)
+	}
+
+	connectCtx, cancel := context.WithTimeout(ctx, serviceEndpointProbeTimeout)
+	defer cancel()
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(connectCtx, "tcp", net.JoinHostPort(proxyUrl.Hostname(), proxyPort))
+	if err != nil {
+		return nil, "", err
+	}
+	if proxyUrl.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyUrl.Hostname()})
+		if err := tlsConn.HandshakeContext(connectCtx); err != nil {
+			conn.Close()
+			return nil, "", err
+		}
+		conn = tlsConn
+	}
+
+	// Give up on the CONNECT request with the rest of the step
+	if deadline, ok := connectCtx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if proxyUrl.User != nil {
+		password, _ := proxyUrl.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyUrl.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, "", err
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(conn), req)
+	if err != nil {
+		conn.Close()
+		return nil, "", err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, resp.Status, fmt.Errorf("proxy CONNECT to %s failed: %s", address, resp.Status)
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return conn, resp.Status, nil
+}
+
+// resolveServiceEndpoint returns the URL requests to the service in the region
+// are sent to. AWS SDK v1 is used because v2 does not expose the endpoint
+// data.
+func resolveServiceEndpoint(d *plugin.QueryData, serviceID string, region string) (*url.URL, error) {
+	awsSpcConfig := GetConfig(d.Connection)
+	if awsSpcConfig.EndpointUrl != nil && *awsSpcConfig.EndpointUrl != "" {
+		return url.Parse(*awsSpcConfig.EndpointUrl)
+	}
+
+	// Match the AWS SDK v2 defaults used by the plugin's clients
+	resolved, err := endpoints.DefaultResolver().EndpointFor(serviceID, region, func(o *endpoints.Options) {
+		o.STSRegionalEndpoint = endpoints.RegionalSTSEndpoint
+		o.S3UsEast1RegionalEndpoint = endpoints.RegionalS3UsEast1Endpoint
+	})
+	if err != nil {
+		return nil, err
+	}
+	return url.Parse(resolved.URL)
+}
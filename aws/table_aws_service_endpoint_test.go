@@ -0,0 +1,66 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+// startTestConnectProxy serves one CONNECT request with the given status, and
+// returns the request it received
+func startTestConnectProxy(t *testing.T, status string) (*url.URL, <-chan *http.Request) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	requests := make(chan *http.Request, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		req, err := http.ReadRequest(bufio.NewReader(conn))
+		if err != nil {
+			return
+		}
+		requests <- req
+		conn.Write([]byte("HTTP/1.1 " + status + "\r\n\r\n"))
+	}()
+
+	return &url.URL{Scheme: "http", User: url.UserPassword("user", "secret"), Host: listener.Addr().String()}, requests
+}
+
+func TestDialServiceEndpointProxy(t *testing.T) {
+	proxyUrl, requests := startTestConnectProxy(t, "200 Connection established")
+	conn, status, err := dialServiceEndpointProxy(context.Background(), proxyUrl, "sts.us-east-1.amazonaws.com:443")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	if status != "200 Connection established" {
+		t.Errorf("status = %q, want 200 Connection established", status)
+	}
+	req := <-requests
+	if req.Method != http.MethodConnect || req.Host != "sts.us-east-1.amazonaws.com:443" {
+		t.Errorf("request = %s %s, want CONNECT sts.us-east-1.amazonaws.com:443", req.Method, req.Host)
+	}
+	if got := req.Header.Get("Proxy-Authorization"); got != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("Proxy-Authorization = %q, want the proxy URL credentials", got)
+	}
+
+	// A refused tunnel reports the proxy's status
+	proxyUrl, _ = startTestConnectProxy(t, "407 Proxy Authentication Required")
+	if _, status, err := dialServiceEndpointProxy(context.Background(), proxyUrl, "sts.us-east-1.amazonaws.com:443"); err == nil || status != "407 Proxy Authentication Required" {
+		t.Errorf("status = %q, err = %v, want 407 Proxy Authentication Required and an error", status, err)
+	}
+
+	if _, _, err := dialServiceEndpointProxy(context.Background(), &url.URL{Scheme: "socks5", Host: "127.0.0.1:1080"}, "sts.us-east-1.amazonaws.com:443"); err == nil {
+		t.Error("socks5 proxy: err = nil, want unsupported scheme error")
+	}
+}
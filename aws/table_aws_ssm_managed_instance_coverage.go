@@ -0,0 +1,533 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2Types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmTypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+
+	"github.com/aws/smithy-go"
+
+	ssmv1 "github.com/aws/aws-sdk-go/service/ssm"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// VPC endpoint services required by the SSM agent when an instance has no
+// route to the public SSM endpoints
+var ssmRequiredVpcEndpointServices = []string{"ssm", "ssmmessages", "ec2messages"}
+
+// The service setting that holds the role of Default Host Management
+// Configuration, which gives the SSM agent credentials without an instance
+// profile
+const ssmDefaultHostManagementRoleSettingId = "/ssm/managed-instance/default-ec2-instance-management-role"
+
+//// TABLE DEFINITION
+
+func tableAwsSSMManagedInstanceCoverage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_ssm_managed_instance_coverage",
+		Description: "AWS SSM Managed Instance Coverage",
+		List: &plugin.ListConfig{
+			Hydrate: listSsmManagedInstanceCoverage,
+			Tags:    map[string]string{"service": "ec2", "action": "DescribeInstances"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "instance_id", Require: plugin.Optional},
+				{Name: "vpc_id", Require: plugin.Optional},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(ssmv1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "instance_id",
+				Description: "The ID of the EC2 instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_arn",
+				Description: "The Amazon Resource Name (ARN) of the EC2 instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_state",
+				Description: "The state of the EC2 instance. Only running instances are listed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "coverage_status",
+				Description: "The SSM coverage of the instance. Possible values are: managed (reporting as Online), not_reporting (known to SSM, but not Online) or unmanaged (no managed instance record).",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "likely_cause",
+				Description: "The most likely reason the instance is not reporting to SSM. Possible values are: missing_instance_profile (no instance profile, and Default Host Management Configuration is not enabled in the region), no_network_path or unknown. Null if the instance is managed.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "ping_status",
+				Description: "The SSM connection status of the instance, if it has a managed instance record.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "last_ping_date_time",
+				Description: "The date and time when the SSM agent last pinged the Systems Manager service.",
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "days_since_last_ping",
+				Description: "The number of whole days since the SSM agent last pinged the Systems Manager service.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "agent_version",
+				Description: "The version of the SSM agent running on the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "platform_type",
+				Description: "The operating system platform type reported by the SSM agent.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "iam_instance_profile_arn",
+				Description: "The Amazon Resource Name (ARN) of the IAM instance profile associated with the instance.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "has_instance_profile",
+				Description: "Indicates whether an IAM instance profile is associated with the instance. Without one, the SSM agent has no credentials, unless Default Host Management Configuration is enabled.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "default_host_management_role",
+				Description: "The IAM role used by Default Host Management Configuration in the region, which gives the SSM agent credentials when the instance has no instance profile. Null if it is not enabled, or the setting cannot be read.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "vpc_id",
+				Description: "The ID of the VPC that the instance is running in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "subnet_id",
+				Description: "The ID of the subnet that the instance is running in.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "public_ip_address",
+				Description: "The public IPv4 address assigned to the instance, if any.",
+				Type:        proto.ColumnType_IPADDR,
+			},
+			{
+				Name:        "default_route_target",
+				Description: "The target (e.g. internet gateway, NAT gateway or transit gateway ID) of the active default (0.0.0.0/0) route in the route table of the instance's subnet.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "has_internet_egress",
+				Description: "Indicates whether the instance can reach the public SSM endpoints, i.e. its subnet has a default route through a NAT or transit gateway, or through an internet gateway and the instance has a public IP address.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "ssm_vpc_endpoints",
+				Description: "The SSM related VPC endpoint services (ssm, ssmmessages, ec2messages) that have an available interface endpoint in the instance's VPC.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "has_ssm_vpc_endpoints",
+				Description: "Indicates whether the instance's VPC has available interface endpoints for all of ssm, ssmmessages and ec2messages.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("InstanceId"),
+			},
+			{
+				Name:        "akas",
+				Description: resourceInterfaceDescription("akas"),
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.FromField("InstanceArn").Transform(transform.EnsureStringArray),
+			},
+		}),
+	}
+}
+
+type ssmManagedInstanceCoverage struct {
+	InstanceId                *string
+	InstanceArn               string
+	InstanceState             ec2Types.InstanceStateName
+	CoverageStatus            string
+	LikelyCause               *string
+	PingStatus                ssmTypes.PingStatus
+	LastPingDateTime          *time.Time
+	DaysSinceLastPing         *int
+	AgentVersion              *string
+	PlatformType              ssmTypes.PlatformType
+	IamInstanceProfileArn     *string
+	HasInstanceProfile        bool
+	DefaultHostManagementRole *string
+	VpcId                     *string
+	SubnetId                  *string
+	PublicIpAddress           *string
+	DefaultRouteTarget        *string
+	HasInternetEgress         bool
+	SsmVpcEndpoints           []string
+	HasSsmVpcEndpoints        bool
+}
+
+//// LIST FUNCTION
+
+func listSsmManagedInstanceCoverage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+
+	// Create sessions
+	ssmSvc, err := SSMClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "connection_error", err)
+		return nil, err
+	}
+	if ssmSvc == nil {
+		// Unsupported region check
+		return nil, nil
+	}
+	ec2Svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "connection_error", err)
+		return nil, err
+	}
+
+	c, err := getCommonColumns(ctx, d, h)
+	if err != nil {
+		return nil, err
+	}
+	commonColumnData := c.(*awsCommonColumnData)
+
+	// Managed instances, keyed by instance ID
+	managedInstances, err := listSsmInstanceInformationByInstanceId(ctx, d, ssmSvc)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "api_error", err)
+		return nil, err
+	}
+
+	defaultHostManagementRole, err := getSsmDefaultHostManagementRole(ctx, ssmSvc)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "api_error", err)
+		return nil, err
+	}
+
+	// Network paths to the SSM endpoints. These are collected lazily per VPC
+	// and subnet, since most instances share a small number of them.
+	vpcEndpointServices := map[string][]string{}
+	subnetDefaultRouteTargets := map[string]*string{}
+
+	input := &ec2.DescribeInstancesInput{
+		MaxResults: aws.Int32(1000),
+		Filters: []ec2Types.Filter{
+			{
+				Name:   aws.String("instance-state-name"),
+				Values: []string{string(ec2Types.InstanceStateNameRunning)},
+			},
+		},
+	}
+	if d.EqualsQualString("vpc_id") != "" {
+		input.Filters = append(input.Filters, ec2Types.Filter{
+			Name:   aws.String("vpc-id"),
+			Values: []string{d.EqualsQualString("vpc_id")},
+		})
+	}
+	if d.EqualsQualString("instance_id") != "" {
+		input.InstanceIds = []string{d.EqualsQualString("instance_id")}
+		input.MaxResults = nil
+	}
+
+	paginator := ec2.NewDescribeInstancesPaginator(ec2Svc, input, func(o *ec2.DescribeInstancesPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "api_error", err)
+			return nil, err
+		}
+
+		for _, reservation := range output.Reservations {
+			for _, instance := range reservation.Instances {
+				item := ssmManagedInstanceCoverage{
+					InstanceId:      instance.InstanceId,
+					InstanceArn:     "arn:" + commonColumnData.Partition + ":ec2:" + region + ":" + commonColumnData.AccountId + ":instance/" + *instance.InstanceId,
+					VpcId:           instance.VpcId,
+					SubnetId:        instance.SubnetId,
+					PublicIpAddress: instance.PublicIpAddress,
+				}
+				if instance.State != nil {
+					item.InstanceState = instance.State.Name
+				}
+				if instance.IamInstanceProfile != nil {
+					item.IamInstanceProfileArn = instance.IamInstanceProfile.Arn
+					item.HasInstanceProfile = true
+				}
+				item.DefaultHostManagementRole = defaultHostManagementRole
+
+				if instance.VpcId != nil {
+					services, ok := vpcEndpointServices[*instance.VpcId]
+					if !ok {
+						services, err = getSsmVpcEndpointServices(ctx, d, ec2Svc, region, *instance.VpcId)
+						if err != nil {
+							plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "api_error", err)
+							return nil, err
+						}
+						vpcEndpointServices[*instance.VpcId] = services
+					}
+					item.SsmVpcEndpoints = services
+					item.HasSsmVpcEndpoints = len(services) == len(ssmRequiredVpcEndpointServices)
+				}
+
+				if instance.SubnetId != nil {
+					target, ok := subnetDefaultRouteTargets[*instance.SubnetId]
+					if !ok {
+						target, err = getSubnetDefaultRouteTarget(ctx, d, ec2Svc, *instance.VpcId, *instance.SubnetId)
+						if err != nil {
+							plugin.Logger(ctx).Error("aws_ssm_managed_instance_coverage.listSsmManagedInstanceCoverage", "api_error", err)
+							return nil, err
+						}
+						subnetDefaultRouteTargets[*instance.SubnetId] = target
+					}
+					item.DefaultRouteTarget = target
+					// Traffic through an internet gateway also requires a public IP address
+					item.HasInternetEgress = target != nil && (!strings.HasPrefix(*target, "igw-") || instance.PublicIpAddress != nil)
+				}
+
+				if info, ok := managedInstances[*instance.InstanceId]; ok {
+					item.PingStatus = info.PingStatus
+					item.LastPingDateTime = info.LastPingDateTime
+					item.AgentVersion = info.AgentVersion
+					item.PlatformType = info.PlatformType
+					if info.LastPingDateTime != nil {
+						days := int(time.Since(*info.LastPingDateTime).Hours() / 24)
+						item.DaysSinceLastPing = &days
+					}
+				}
+
+				item.CoverageStatus, item.LikelyCause = ssmCoverageStatus(item)
+
+				d.StreamListItem(ctx, item)
+
+				// Context may get cancelled due to manual cancellation or if the limit has been reached
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// UTILITY FUNCTIONS
+
+func listSsmInstanceInformationByInstanceId(ctx context.Context, d *plugin.QueryData, svc *ssm.Client) (map[string]ssmTypes.InstanceInformation, error) {
+	managedInstances := map[string]ssmTypes.InstanceInformation{}
+
+	input := &ssm.DescribeInstanceInformationInput{
+		MaxResults: aws.Int32(50),
+	}
+	if d.EqualsQualString("instance_id") != "" {
+		input.Filters = []ssmTypes.InstanceInformationStringFilter{
+			{
+				Key:    aws.String("InstanceIds"),
+				Values: []string{d.EqualsQualString("instance_id")},
+			},
+		}
+	}
+
+	paginator := ssm.NewDescribeInstanceInformationPaginator(svc, input, func(o *ssm.DescribeInstanceInformationPaginatorOptions) {
+		o.Limit = 50
+		o.StopOnDuplicateToken = true
+	})
+
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range output.InstanceInformationList {
+			if info.InstanceId != nil {
+				managedInstances[*info.InstanceId] = info
+			}
+		}
+	}
+
+	return managedInstances, nil
+}
+
+// getSsmDefaultHostManagementRole returns the role of Default Host Management
+// Configuration in the client's region, or nil if it is not enabled. The
+// setting is optional context for likely_cause, so nil is also returned if
+// the caller cannot read it.
+func getSsmDefaultHostManagementRole(ctx context.Context, svc *ssm.Client) (*string, error) {
+	output, err := svc.GetServiceSetting(ctx, &ssm.GetServiceSettingInput{
+		SettingId: aws.String(ssmDefaultHostManagementRoleSettingId),
+	})
+	if err != nil {
+		var ae smithy.APIError
+		if errors.As(err, &ae) && (ae.ErrorCode() == "ServiceSettingNotFound" || ae.ErrorCode() == "AccessDeniedException") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if output.ServiceSetting == nil || aws.ToString(output.ServiceSetting.SettingValue) == "" {
+		return nil, nil
+	}
+	return output.ServiceSetting.SettingValue, nil
+}
+
+// getSsmVpcEndpointServices returns which of the services required by the
+// SSM agent have an available VPC endpoint in the VPC
+func getSsmVpcEndpointServices(ctx context.Context, d *plugin.QueryData, svc *ec2.Client, region string, vpcId string) ([]string, error) {
+	serviceNames := []string{}
+	for _, service := range ssmRequiredVpcEndpointServices {
+		serviceNames = append(serviceNames, "com.amazonaws."+region+"."+service)
+	}
+
+	input := &ec2.DescribeVpcEndpointsInput{
+		Filters: []ec2Types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcId},
+			},
+			{
+				Name:   aws.String("service-name"),
+				Values: serviceNames,
+			},
+			{
+				Name:   aws.String("vpc-endpoint-state"),
+				Values: []string{"available"},
+			},
+		},
+	}
+
+	found := map[string]bool{}
+	paginator := ec2.NewDescribeVpcEndpointsPaginator(svc, input, func(o *ec2.DescribeVpcEndpointsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		// apply rate limiting
+		d.WaitForListRateLimit(ctx)
+
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range output.VpcEndpoints {
+			if endpoint.ServiceName != nil {
+				found[strings.TrimPrefix(*endpoint.ServiceName, "com.amazonaws."+region+".")] = true
+			}
+		}
+	}
+
+	services := []string{}
+	for _, service := range ssmRequiredVpcEndpointServices {
+		if found[service] {
+			services = append(services, service)
+		}
+	}
+	return services, nil
+}
+
+// getSubnetDefaultRouteTarget returns the target of the active 0.0.0.0/0
+// route in the route table used by the subnet (either explicitly associated,
+// or the VPC main route table), or nil if there is none
+func getSubnetDefaultRouteTarget(ctx context.Context, d *plugin.QueryData, svc *ec2.Client, vpcId string, subnetId string) (*string, error) {
+	// apply rate limiting
+	d.WaitForListRateLimit(ctx)
+
+	output, err := svc.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+		Filters: []ec2Types.Filter{
+			{
+				Name:   aws.String("association.subnet-id"),
+				Values: []string{subnetId},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	routeTables := output.RouteTables
+	if len(routeTables) == 0 {
+		// Subnets without an explicit association use the main route table
+		d.WaitForListRateLimit(ctx)
+		output, err = svc.DescribeRouteTables(ctx, &ec2.DescribeRouteTablesInput{
+			Filters: []ec2Types.Filter{
+				{
+					Name:   aws.String("vpc-id"),
+					Values: []string{vpcId},
+				},
+				{
+					Name:   aws.String("association.main"),
+					Values: []string{"true"},
+				},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		routeTables = output.RouteTables
+	}
+
+	for _, routeTable := range routeTables {
+		for _, route := range routeTable.Routes {
+			if aws.ToString(route.DestinationCidrBlock) != "0.0.0.0/0" || route.State != ec2Types.RouteStateActive {
+				continue
+			}
+			for _, target := range []*string{route.GatewayId, route.NatGatewayId, route.TransitGatewayId, route.NetworkInterfaceId, route.VpcPeeringConnectionId} {
+				if target != nil {
+					return target, nil
+				}
+			}
+		}
+	}
+	return nil, nil
+}
+
+// ssmCoverageStatus classifies the instance's SSM coverage and, if it is not
+// managed, the most likely reason why
+func ssmCoverageStatus(item ssmManagedInstanceCoverage) (string, *string) {
+	if item.PingStatus == ssmTypes.PingStatusOnline {
+		return "managed", nil
+	}
+
+	status := "unmanaged"
+	if item.PingStatus != "" {
+		status = "not_reporting"
+	}
+
+	// Without an instance profile, Default Host Management Configuration can
+	// still give the agent credentials
+	cause := "unknown"
+	if !item.HasInstanceProfile && item.DefaultHostManagementRole == nil {
+		cause = "missing_instance_profile"
+	} else if !item.HasInternetEgress && !item.HasSsmVpcEndpoints {
+		cause = "no_network_path"
+	}
+	return status, &cause
+}
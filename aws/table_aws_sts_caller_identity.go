@@ -2,6 +2,10 @@ package aws
 
 import (
 	"context"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -27,6 +31,51 @@ func tableAwsSTSCallerIdentity(_ context.Context) *plugin.Table {
 				Description: "The unique identifier of the calling entity. The exact value depends on the type of entity that is making the call.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "principal_type",
+				Description: "The type of the calling entity. Possible values are: root, user, assumed-role and federated-user.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Arn").Transform(stsCallerIdentityArnPart("type")),
+			},
+			{
+				Name:        "principal_name",
+				Description: "The name of the IAM user, the role of an assumed-role session or the federated user.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Arn").Transform(stsCallerIdentityArnPart("name")),
+			},
+			{
+				Name:        "role_arn",
+				Description: "The ARN of the IAM role, if the calling entity is an assumed-role session. The role path is not part of the session ARN, so it is omitted.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Arn").Transform(stsCallerIdentityArnPart("role_arn")),
+			},
+			{
+				Name:        "session_name",
+				Description: "The role session name, if the calling entity is an assumed-role session.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Arn").Transform(stsCallerIdentityArnPart("session_name")),
+			},
+			{
+				Name:        "credential_source",
+				Description: "The credential provider the connection credentials were loaded from, e.g. SharedConfigCredentials, EnvConfigCredentials, AssumeRoleProvider or SSOProvider.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getStsCallerCredentials,
+				Transform:   transform.FromField("Source"),
+			},
+			{
+				Name:        "access_key_id",
+				Description: "The access key ID of the connection credentials.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getStsCallerCredentials,
+				Transform:   transform.FromField("AccessKeyID"),
+			},
+			{
+				Name:        "credentials_expire_time",
+				Description: "The time the connection credentials expire, if they are temporary.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Hydrate:     getStsCallerCredentials,
+				Transform:   transform.FromField("Expires"),
+			},
 
 			// Steampipe standard columns
 			{
@@ -59,3 +108,83 @@ func getStsCallerIdentity(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 
 	return nil, nil
 }
+
+type stsCallerCredentials struct {
+	Source      string
+	AccessKeyID string
+	Expires     *time.Time
+}
+
+//// HYDRATE FUNCTIONS
+
+func getStsCallerCredentials(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	cfg, err := getClientForDefaultRegion(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_sts_caller_identity.getStsCallerCredentials", "client_error", err)
+		return nil, err
+	}
+
+	credentials, err := cfg.Credentials.Retrieve(ctx)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_sts_caller_identity.getStsCallerCredentials", "credentials_error", err)
+		return nil, err
+	}
+
+	result := &stsCallerCredentials{
+		Source:      credentials.Source,
+		AccessKeyID: credentials.AccessKeyID,
+	}
+	if credentials.CanExpire {
+		result.Expires = &credentials.Expires
+	}
+	return result, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+// stsCallerIdentityArnPart extracts a part of the caller identity ARN, which is
+// one of:
+//
+//	arn:aws:iam::123456789012:root
+//	arn:aws:iam::123456789012:user/path/UserName
+//	arn:aws:sts::123456789012:assumed-role/RoleName/SessionName
+//	arn:aws:sts::123456789012:federated-user/UserName
+func stsCallerIdentityArnPart(part string) transform.TransformFunc {
+	return func(_ context.Context, d *transform.TransformData) (interface{}, error) {
+		callerArn, ok := d.Value.(*string)
+		if !ok || callerArn == nil {
+			return nil, nil
+		}
+		parsed, err := arn.Parse(*callerArn)
+		if err != nil {
+			return nil, nil
+		}
+
+		segments := strings.Split(parsed.Resource, "/")
+		principalType := segments[0]
+		switch part {
+		case "type":
+			return principalType, nil
+		case "name":
+			if len(segments) < 2 {
+				return nil, nil
+			}
+			if principalType == "assumed-role" {
+				return segments[1], nil
+			}
+			// IAM user names are the last segment, after any path
+			return segments[len(segments)-1], nil
+		case "role_arn":
+			if principalType != "assumed-role" || len(segments) < 2 {
+				return nil, nil
+			}
+			return "arn:" + parsed.Partition + ":iam::" + parsed.AccountID + ":role/" + segments[1], nil
+		case "session_name":
+			if principalType != "assumed-role" || len(segments) < 3 {
+				return nil, nil
+			}
+			return segments[2], nil
+		}
+		return nil, nil
+	}
+}
@@ -29,6 +29,9 @@ func tableAwsTaggingResource(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate: listTaggingResources,
 			Tags:    map[string]string{"service": "tag", "action": "GetResources"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "tags", Require: plugin.Optional, Operators: []string{"@>", "?"}},
+			},
 		},
 		GetMatrixItemFunc: SupportedRegionMatrix(resourcegroupstaggingapiv1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
@@ -106,6 +109,26 @@ func listTaggingResources(ctx context.Context, d *plugin.QueryData, _ *plugin.Hy
 		ResourcesPerPage: aws.Int32(100),
 	}
 
+	// Resources must match every tag filter, so each tag qual can be passed
+	// to the API as is. Only the first filter for a tag key is used.
+	tagKeys := map[string]bool{}
+	for _, tagFilter := range getTagQualFilters(d.Quals, "tags") {
+		if tagKeys[tagFilter.Key] {
+			continue
+		}
+		tagKeys[tagFilter.Key] = true
+		filter := types.TagFilter{
+			Key: aws.String(tagFilter.Key),
+		}
+		if tagFilter.Value != nil {
+			filter.Values = []string{*tagFilter.Value}
+		}
+		input.TagFilters = append(input.TagFilters, filter)
+	}
+	if len(input.TagFilters) > 0 {
+		plugin.Logger(ctx).Debug("aws_tagging_resource.listTaggingResources", "filters_pushed", input.TagFilters)
+	}
+
 	// Reduce the basic request limit down if the user has only requested a small number of rows
 	if d.QueryContext.Limit != nil {
 		limit := int32(*d.QueryContext.Limit)
@@ -0,0 +1,456 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudfront"
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
+
+	elbv2v1 "github.com/aws/aws-sdk-go/service/elbv2"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+type tlsPolicyDetail struct {
+	PolicyName                 string
+	Source                     string
+	SupportedLoadBalancerTypes []string
+	Protocols                  []string
+	Ciphers                    []tlsPolicyCipher
+}
+
+type tlsPolicyCipher struct {
+	Name     string
+	Priority *int32
+	Weak     bool
+}
+
+//// TABLE DEFINITION
+
+func tableAwsTlsPolicyDetail(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_tls_policy_detail",
+		Description: "AWS TLS Policy Detail",
+		List: &plugin.ListConfig{
+			Hydrate: listTlsPolicyDetails,
+			Tags:    map[string]string{"service": "elasticloadbalancing", "action": "DescribeSSLPolicies"},
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "source", Require: plugin.Optional},
+			},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getTlsPolicyUsage,
+				Tags: map[string]string{"service": "elasticloadbalancing", "action": "DescribeListeners"},
+			},
+		},
+		GetMatrixItemFunc: SupportedRegionMatrix(elbv2v1.EndpointsID),
+		Columns: awsRegionalColumns([]*plugin.Column{
+			{
+				Name:        "policy_name",
+				Description: "The name of the security policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source",
+				Description: "The service the security policy belongs to. Possible values are: elbv2, cloudfront.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "supported_load_balancer_types",
+				Description: "The type of load balancer that the policy supports. Only set for elbv2 policies.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "protocols",
+				Description: "The protocols enabled by the policy.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "ciphers",
+				Description: "The ciphers enabled by the policy, with their priority (elbv2 only) and whether the cipher is considered weak.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "allows_ssl_v3",
+				Description: "True if the policy allows SSLv3.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(tlsPolicyAllowsProtocol("SSLv3")),
+			},
+			{
+				Name:        "allows_tls_1_0",
+				Description: "True if the policy allows TLS 1.0.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(tlsPolicyAllowsProtocol("TLSv1")),
+			},
+			{
+				Name:        "allows_tls_1_1",
+				Description: "True if the policy allows TLS 1.1.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(tlsPolicyAllowsProtocol("TLSv1.1")),
+			},
+			{
+				Name:        "has_weak_ciphers",
+				Description: "True if the policy enables at least one weak cipher (RC4, DES, 3DES, MD5, NULL, export, anonymous or SHA-1 CBC cipher suites).",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(tlsPolicyHasWeakCiphers),
+			},
+			{
+				Name:        "weak_ciphers",
+				Description: "The weak ciphers enabled by the policy.",
+				Type:        proto.ColumnType_JSON,
+				Transform:   transform.From(tlsPolicyWeakCiphers),
+			},
+			{
+				Name:        "used_by",
+				Description: "The ARNs of the load balancer listeners (elbv2) or distributions (cloudfront) that use the policy.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getTlsPolicyUsage,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "used_by_count",
+				Description: "The number of load balancer listeners or distributions that use the policy.",
+				Type:        proto.ColumnType_INT,
+				Hydrate:     getTlsPolicyUsage,
+				Transform:   transform.FromValue().Transform(countTlsPolicyUsage),
+			},
+
+			// Steampipe standard columns
+			{
+				Name:        "title",
+				Description: resourceInterfaceDescription("title"),
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("PolicyName"),
+			},
+		}),
+	}
+}
+
+//// LIST FUNCTION
+
+func listTlsPolicyDetails(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	source := d.EqualsQualString("source")
+
+	if source == "" || source == "elbv2" {
+		svc, err := ELBV2Client(ctx, d)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_tls_policy_detail.listTlsPolicyDetails", "connection_error", err)
+			return nil, err
+		}
+
+		params := &elasticloadbalancingv2.DescribeSSLPoliciesInput{
+			PageSize: aws.Int32(400),
+		}
+
+		pagesLeft := true
+		for pagesLeft {
+			// apply rate limiting
+			d.WaitForListRateLimit(ctx)
+
+			response, err := svc.DescribeSSLPolicies(ctx, params)
+			if err != nil {
+				plugin.Logger(ctx).Error("aws_tls_policy_detail.listTlsPolicyDetails", "api_error", err)
+				return nil, err
+			}
+
+			for _, policy := range response.SslPolicies {
+				item := tlsPolicyDetail{
+					PolicyName:                 *policy.Name,
+					Source:                     "elbv2",
+					SupportedLoadBalancerTypes: policy.SupportedLoadBalancerTypes,
+					Protocols:                  policy.SslProtocols,
+				}
+				for _, cipher := range policy.Ciphers {
+					item.Ciphers = append(item.Ciphers, tlsPolicyCipher{
+						Name:     *cipher.Name,
+						Priority: cipher.Priority,
+						Weak:     isWeakTlsCipher(*cipher.Name),
+					})
+				}
+				d.StreamListItem(ctx, item)
+
+				// Context may get cancelled due to manual cancellation or if the limit has been reached
+				if d.RowsRemaining(ctx) == 0 {
+					return nil, nil
+				}
+			}
+
+			pagesLeft = response.NextMarker != nil
+			params.Marker = response.NextMarker
+		}
+	}
+
+	if source == "" || source == "cloudfront" {
+		// CloudFront is a global service, so its security policies are only
+		// listed once, in the default region of the connection
+		region := d.EqualsQualString(matrixKeyRegion)
+		defaultRegion, err := getDefaultRegion(ctx, d, h)
+		if err != nil {
+			return nil, err
+		}
+		if region != defaultRegion {
+			return nil, nil
+		}
+
+		for _, policy := range cloudFrontSecurityPolicies() {
+			d.StreamListItem(ctx, policy)
+
+			// Context may get cancelled due to manual cancellation or if the limit has been reached
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// HYDRATE FUNCTIONS
+
+func getTlsPolicyUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	policy := h.Item.(tlsPolicyDetail)
+
+	var usage interface{}
+	var err error
+	if policy.Source == "cloudfront" {
+		usage, err = getCloudFrontSecurityPolicyUsage(ctx, d, h)
+	} else {
+		usage, err = getElbv2SslPolicyUsage(ctx, d, h)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	usedBy := usage.(map[string][]string)[policy.PolicyName]
+	if usedBy == nil {
+		return []string{}, nil
+	}
+	return usedBy, nil
+}
+
+// The listeners of all load balancers in a region are only listed once per
+// query, rather than once per policy.
+var getElbv2SslPolicyUsageMemoized = plugin.HydrateFunc(getElbv2SslPolicyUsageUncached).Memoize(memoize.WithCacheKeyFunction(getElbv2SslPolicyUsageCacheKey))
+
+func getElbv2SslPolicyUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getElbv2SslPolicyUsageMemoized(ctx, d, h)
+}
+
+func getElbv2SslPolicyUsageCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	region := d.EqualsQualString(matrixKeyRegion)
+	key := fmt.Sprintf("getElbv2SslPolicyUsage-%s", region)
+	return key, nil
+}
+
+// getElbv2SslPolicyUsageUncached returns the listener ARNs for each SSL policy in the region
+func getElbv2SslPolicyUsageUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := ELBV2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_tls_policy_detail.getElbv2SslPolicyUsage", "connection_error", err)
+		return nil, err
+	}
+
+	usage := map[string][]string{}
+
+	paginator := elasticloadbalancingv2.NewDescribeLoadBalancersPaginator(svc, &elasticloadbalancingv2.DescribeLoadBalancersInput{}, func(o *elasticloadbalancingv2.DescribeLoadBalancersPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_tls_policy_detail.getElbv2SslPolicyUsage", "api_error", err)
+			return nil, err
+		}
+
+		for _, loadBalancer := range output.LoadBalancers {
+			// Gateway load balancers do not have TLS listeners
+			if loadBalancer.Type == "gateway" {
+				continue
+			}
+
+			listenerPaginator := elasticloadbalancingv2.NewDescribeListenersPaginator(svc, &elasticloadbalancingv2.DescribeListenersInput{
+				LoadBalancerArn: loadBalancer.LoadBalancerArn,
+			}, func(o *elasticloadbalancingv2.DescribeListenersPaginatorOptions) {
+				o.StopOnDuplicateToken = true
+			})
+			for listenerPaginator.HasMorePages() {
+				listeners, err := listenerPaginator.NextPage(ctx)
+				if err != nil {
+					plugin.Logger(ctx).Error("aws_tls_policy_detail.getElbv2SslPolicyUsage", "api_error", err)
+					return nil, err
+				}
+
+				for _, listener := range listeners.Listeners {
+					if listener.SslPolicy != nil {
+						usage[*listener.SslPolicy] = append(usage[*listener.SslPolicy], *listener.ListenerArn)
+					}
+				}
+			}
+		}
+	}
+
+	return usage, nil
+}
+
+var getCloudFrontSecurityPolicyUsageMemoized = plugin.HydrateFunc(getCloudFrontSecurityPolicyUsageUncached).Memoize()
+
+func getCloudFrontSecurityPolicyUsage(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getCloudFrontSecurityPolicyUsageMemoized(ctx, d, h)
+}
+
+// getCloudFrontSecurityPolicyUsageUncached returns the distribution ARNs for each CloudFront security policy
+func getCloudFrontSecurityPolicyUsageUncached(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	svc, err := CloudFrontClient(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_tls_policy_detail.getCloudFrontSecurityPolicyUsage", "connection_error", err)
+		return nil, err
+	}
+
+	usage := map[string][]string{}
+
+	paginator := cloudfront.NewListDistributionsPaginator(svc, &cloudfront.ListDistributionsInput{}, func(o *cloudfront.ListDistributionsPaginatorOptions) {
+		o.StopOnDuplicateToken = true
+	})
+	for paginator.HasMorePages() {
+		output, err := paginator.NextPage(ctx)
+		if err != nil {
+			plugin.Logger(ctx).Error("aws_tls_policy_detail.getCloudFrontSecurityPolicyUsage", "api_error", err)
+			return nil, err
+		}
+
+		for _, distribution := range output.DistributionList.Items {
+			if distribution.ViewerCertificate == nil {
+				continue
+			}
+			// Distributions using the default CloudFront certificate always use the
+			// TLSv1 security policy, whatever the minimum protocol version is set to
+			policyName := string(distribution.ViewerCertificate.MinimumProtocolVersion)
+			if aws.ToBool(distribution.ViewerCertificate.CloudFrontDefaultCertificate) {
+				policyName = "TLSv1"
+			}
+			usage[policyName] = append(usage[policyName], *distribution.ARN)
+		}
+	}
+
+	return usage, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func tlsPolicyAllowsProtocol(protocol string) transform.TransformFunc {
+	return func(_ context.Context, d *transform.TransformData) (interface{}, error) {
+		policy := d.HydrateItem.(tlsPolicyDetail)
+		for _, p := range policy.Protocols {
+			if p == protocol {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+}
+
+func tlsPolicyHasWeakCiphers(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	policy := d.HydrateItem.(tlsPolicyDetail)
+	for _, cipher := range policy.Ciphers {
+		if cipher.Weak {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func tlsPolicyWeakCiphers(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	policy := d.HydrateItem.(tlsPolicyDetail)
+	weakCiphers := []string{}
+	for _, cipher := range policy.Ciphers {
+		if cipher.Weak {
+			weakCiphers = append(weakCiphers, cipher.Name)
+		}
+	}
+	if len(weakCiphers) == 0 {
+		return nil, nil
+	}
+	return weakCiphers, nil
+}
+
+func countTlsPolicyUsage(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	return len(d.Value.([]string)), nil
+}
+
+//// UTILITY FUNCTIONS
+
+// isWeakTlsCipher returns true for cipher suites (in OpenSSL naming) that use
+// broken or deprecated algorithms, or CBC mode with a SHA-1 MAC
+func isWeakTlsCipher(name string) bool {
+	for _, weak := range []string{"RC4", "DES", "MD5", "NULL", "EXP", "anon", "ADH", "AECDH"} {
+		if strings.Contains(name, weak) {
+			return true
+		}
+	}
+	return strings.HasSuffix(name, "-SHA")
+}
+
+// cloudFrontSecurityPolicies returns the protocols and ciphers of the security
+// policies that CloudFront supports between viewers and CloudFront. CloudFront
+// does not provide an API for these, so they are taken from
+// https://docs.aws.amazon.com/AmazonCloudFront/latest/DeveloperGuide/secure-connections-supported-viewer-protocols-ciphers.html
+func cloudFrontSecurityPolicies() []tlsPolicyDetail {
+	tls13Ciphers := []string{"TLS_AES_128_GCM_SHA256", "TLS_AES_256_GCM_SHA384", "TLS_CHACHA20_POLY1305_SHA256"}
+	ecdheAeadCiphers := []string{"ECDHE-RSA-AES128-GCM-SHA256", "ECDHE-ECDSA-AES128-GCM-SHA256", "ECDHE-RSA-AES256-GCM-SHA384", "ECDHE-ECDSA-AES256-GCM-SHA384", "ECDHE-RSA-CHACHA20-POLY1305", "ECDHE-ECDSA-CHACHA20-POLY1305"}
+	ecdheSha2Ciphers := []string{"ECDHE-RSA-AES128-SHA256", "ECDHE-ECDSA-AES128-SHA256", "ECDHE-RSA-AES256-SHA384", "ECDHE-ECDSA-AES256-SHA384"}
+	rsaSha2Ciphers := []string{"AES128-GCM-SHA256", "AES256-GCM-SHA384", "AES128-SHA256"}
+	sha1Ciphers := []string{"ECDHE-RSA-AES128-SHA", "ECDHE-ECDSA-AES128-SHA", "ECDHE-RSA-AES256-SHA", "ECDHE-ECDSA-AES256-SHA", "AES128-SHA", "AES256-SHA"}
+
+	concat := func(lists ...[]string) []string {
+		var result []string
+		for _, l := range lists {
+			result = append(result, l...)
+		}
+		return result
+	}
+
+	tls2021 := concat(tls13Ciphers, ecdheAeadCiphers)
+	tls2019 := concat(tls2021, ecdheSha2Ciphers)
+	tls2018 := concat(tls2019, rsaSha2Ciphers)
+	tls2016 := concat(tls2018, sha1Ciphers)
+	tlsv1 := concat(tls2016, []string{"DES-CBC3-SHA"})
+	sslv3 := concat(tlsv1, []string{"RC4-MD5"})
+
+	policies := []struct {
+		name      string
+		protocols []string
+		ciphers   []string
+	}{
+		{"SSLv3", []string{"SSLv3", "TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}, sslv3},
+		{"TLSv1", []string{"TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}, tlsv1},
+		{"TLSv1_2016", []string{"TLSv1", "TLSv1.1", "TLSv1.2", "TLSv1.3"}, tls2016},
+		{"TLSv1.1_2016", []string{"TLSv1.1", "TLSv1.2", "TLSv1.3"}, tls2016},
+		{"TLSv1.2_2018", []string{"TLSv1.2", "TLSv1.3"}, tls2018},
+		{"TLSv1.2_2019", []string{"TLSv1.2", "TLSv1.3"}, tls2019},
+		{"TLSv1.2_2021", []string{"TLSv1.2", "TLSv1.3"}, tls2021},
+	}
+
+	var result []tlsPolicyDetail
+	for _, p := range policies {
+		item := tlsPolicyDetail{
+			PolicyName: p.name,
+			Source:     "cloudfront",
+			Protocols:  p.protocols,
+		}
+		for _, cipher := range p.ciphers {
+			item.Ciphers = append(item.Ciphers, tlsPolicyCipher{
+				Name: cipher,
+				Weak: isWeakTlsCipher(cipher),
+			})
+		}
+		result = append(result, item)
+	}
+	return result
+}
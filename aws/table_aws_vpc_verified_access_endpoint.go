@@ -42,6 +42,12 @@ func tableAwsVpcVerifiedAccessEndpoint(_ context.Context) *plugin.Table {
 				{Name: "verified_access_instance_id", Require: plugin.Optional},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcVerifiedAccessEndpointPolicy,
+				Tags: map[string]string{"service": "ec2", "action": "GetVerifiedAccessEndpointPolicy"},
+			},
+		},
 		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
@@ -130,6 +136,18 @@ func tableAwsVpcVerifiedAccessEndpoint(_ context.Context) *plugin.Table {
 				Description: "The endpoint status.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "policy_enabled",
+				Description: "Indicates whether the Verified Access policy of the endpoint is enabled.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getVpcVerifiedAccessEndpointPolicy,
+			},
+			{
+				Name:        "policy_document",
+				Description: "The Verified Access policy document of the endpoint, written in the Cedar policy language.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcVerifiedAccessEndpointPolicy,
+			},
 			{
 				Name:        "tags_src",
 				Description: resourceInterfaceDescription("tags"),
@@ -256,6 +274,31 @@ func getVpcVerifiedAccessEndpoint(ctx context.Context, d *plugin.QueryData, _ *p
 	return nil, nil
 }
 
+func getVpcVerifiedAccessEndpointPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	endpointId := h.Item.(types.VerifiedAccessEndpoint).VerifiedAccessEndpointId
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_verified_access_endpoint.getVpcVerifiedAccessEndpointPolicy", "connection_error", err)
+		return nil, err
+	}
+
+	// Build the params
+	input := &ec2.GetVerifiedAccessEndpointPolicyInput{
+		VerifiedAccessEndpointId: endpointId,
+	}
+
+	// Get call
+	op, err := svc.GetVerifiedAccessEndpointPolicy(ctx, input)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_verified_access_endpoint.getVpcVerifiedAccessEndpointPolicy", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func endpointTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
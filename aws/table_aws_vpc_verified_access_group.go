@@ -41,6 +41,12 @@ func tableAwsVpcVerifiedAccessGroup(_ context.Context) *plugin.Table {
 				{Name: "verified_access_instance_id", Require: plugin.Optional},
 			},
 		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getVpcVerifiedAccessGroupPolicy,
+				Tags: map[string]string{"service": "ec2", "action": "GetVerifiedAccessGroupPolicy"},
+			},
+		},
 		GetMatrixItemFunc: SupportedRegionMatrix(ec2v1.EndpointsID),
 		Columns: awsRegionalColumns([]*plugin.Column{
 			{
@@ -84,6 +90,18 @@ func tableAwsVpcVerifiedAccessGroup(_ context.Context) *plugin.Table {
 				Description: "The AWS account number that owns the group.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "policy_enabled",
+				Description: "Indicates whether the Verified Access policy of the group is enabled.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getVpcVerifiedAccessGroupPolicy,
+			},
+			{
+				Name:        "policy_document",
+				Description: "The Verified Access policy document of the group, written in the Cedar policy language.",
+				Type:        proto.ColumnType_STRING,
+				Hydrate:     getVpcVerifiedAccessGroupPolicy,
+			},
 			{
 				Name:        "tags_src",
 				Description: resourceInterfaceDescription("tags"),
@@ -212,6 +230,31 @@ func getVpcVerifiedAccessGroup(ctx context.Context, d *plugin.QueryData, _ *plug
 	return nil, nil
 }
 
+func getVpcVerifiedAccessGroupPolicy(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	groupId := h.Item.(types.VerifiedAccessGroup).VerifiedAccessGroupId
+
+	// Create session
+	svc, err := EC2Client(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_verified_access_group.getVpcVerifiedAccessGroupPolicy", "connection_error", err)
+		return nil, err
+	}
+
+	// Build the params
+	input := &ec2.GetVerifiedAccessGroupPolicyInput{
+		VerifiedAccessGroupId: groupId,
+	}
+
+	// Get call
+	op, err := svc.GetVerifiedAccessGroupPolicy(ctx, input)
+	if err != nil {
+		plugin.Logger(ctx).Error("aws_vpc_verified_access_group.getVpcVerifiedAccessGroupPolicy", "api_error", err)
+		return nil, err
+	}
+
+	return op, nil
+}
+
 //// TRANSFORM FUNCTIONS
 
 func verifiedAccessGroupTurbotTags(_ context.Context, d *transform.TransformData) (interface{}, error) {
@@ -0,0 +1,76 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableAwsWellKnownPublicAccount(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "aws_wellknown_public_account",
+		Description: "AWS Well-Known Public Account",
+		List: &plugin.ListConfig{
+			Hydrate: listWellKnownPublicAccounts,
+			KeyColumns: []*plugin.KeyColumn{
+				{Name: "account_id", Require: plugin.Optional},
+				{Name: "service", Require: plugin.Optional},
+			},
+		},
+		Columns: []*plugin.Column{
+			{
+				Name:        "account_id",
+				Description: "The ID of the AWS owned account.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "service",
+				Description: "The service that uses the account, e.g. elasticloadbalancing. Null for accounts added in the connection config.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Service").NullIfZero(),
+			},
+			{
+				Name:        "region",
+				Description: "The region the service uses the account in. Null for accounts added in the connection config.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("Region").NullIfZero(),
+			},
+			{
+				Name:        "description",
+				Description: "What the account is used for.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source",
+				Description: "Where the account comes from. Possible values are: built_in (shipped with the plugin) or config (the wellknown_accounts connection config argument).",
+				Type:        proto.ColumnType_STRING,
+			},
+		},
+	}
+}
+
+//// LIST FUNCTION
+
+func listWellKnownPublicAccounts(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	accountId := d.EqualsQualString("account_id")
+	service := d.EqualsQualString("service")
+
+	for _, account := range getWellKnownAccounts(d) {
+		if (accountId != "" && account.AccountId != accountId) || (service != "" && account.Service != service) {
+			continue
+		}
+
+		d.StreamListItem(ctx, account)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,123 @@
+package aws
+
+import (
+	"context"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const telemetryTracerName = "github.com/turbot/steampipe-plugin-aws"
+
+// Tracer providers are shared by all connections exporting to the same
+// endpoint, so each collector gets a single gRPC connection and batch
+// processor no matter how many connections and regions are queried.
+var (
+	telemetryTracerProvidersMutex sync.Mutex
+	telemetryTracerProviders      = map[telemetryExporterConfig]*sdktrace.TracerProvider{}
+)
+
+type telemetryExporterConfig struct {
+	Endpoint string
+	Insecure bool
+}
+
+// getTelemetryTracerProvider returns the tracer provider exporting spans to the
+// OTLP endpoint configured for the connection, or nil if telemetry is not
+// enabled for it.
+func getTelemetryTracerProvider(ctx context.Context, d *plugin.QueryData) (trace.TracerProvider, error) {
+	awsSpcConfig := GetConfig(d.Connection)
+	if awsSpcConfig.OtelEndpoint == nil || *awsSpcConfig.OtelEndpoint == "" {
+		return nil, nil
+	}
+
+	exporterConfig := telemetryExporterConfig{Endpoint: *awsSpcConfig.OtelEndpoint}
+	if awsSpcConfig.OtelInsecure != nil {
+		exporterConfig.Insecure = *awsSpcConfig.OtelInsecure
+	}
+
+	telemetryTracerProvidersMutex.Lock()
+	defer telemetryTracerProvidersMutex.Unlock()
+
+	if tp, ok := telemetryTracerProviders[exporterConfig]; ok {
+		return tp, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(exporterConfig.Endpoint)}
+	if exporterConfig.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	// The exporter connects lazily, so an unreachable collector does not fail
+	// the query; spans are dropped instead
+	exporter, err := otlptracegrpc.New(context.Background(), opts...)
+	if err != nil {
+		plugin.Logger(ctx).Error("getTelemetryTracerProvider", "endpoint", exporterConfig.Endpoint, "exporter_error", err)
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", "steampipe-plugin-aws"))),
+	)
+	telemetryTracerProviders[exporterConfig] = tp
+
+	return tp, nil
+}
+
+// addTelemetryMiddleware adds a span around every API call made with the
+// config. The span covers all attempts of the call, including retries and
+// backoff, and is a child of any span in the query context.
+func addTelemetryMiddleware(cfg *aws.Config, tp trace.TracerProvider, connectionName string) {
+	tracer := tp.Tracer(telemetryTracerName)
+
+	telemetryMiddleware := middleware.InitializeMiddlewareFunc("SteampipeTelemetry", func(
+		ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+	) (out middleware.InitializeOutput, metadata middleware.Metadata, err error) {
+		serviceId := awsmiddleware.GetServiceID(ctx)
+		operation := awsmiddleware.GetOperationName(ctx)
+
+		ctx, span := tracer.Start(ctx, serviceId+"."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("rpc.system", "aws-api"),
+				attribute.String("rpc.service", serviceId),
+				attribute.String("rpc.method", operation),
+				attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+				attribute.String("steampipe.connection", connectionName),
+			),
+		)
+		defer span.End()
+
+		out, metadata, err = next.HandleInitialize(ctx, in)
+
+		if attempts, ok := retry.GetAttemptResults(metadata); ok && len(attempts.Results) > 0 {
+			span.SetAttributes(attribute.Int("aws.retry_count", len(attempts.Results)-1))
+		}
+		if requestId, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+			span.SetAttributes(attribute.String("aws.request_id", requestId))
+		}
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+
+		return out, metadata, err
+	})
+
+	cfg.APIOptions = append(cfg.APIOptions, func(stack *middleware.Stack) error {
+		// Added last in the initialize step, after the service metadata
+		// (service, operation and region) has been registered on the context
+		return stack.Initialize.Add(telemetryMiddleware, middleware.After)
+	})
+}
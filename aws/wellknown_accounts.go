@@ -0,0 +1,148 @@
+package aws
+
+import (
+	"sort"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Well-known public accounts are AWS owned accounts that customers grant
+// access to so an AWS service can deliver data to them, e.g. the account that
+// writes Elastic Load Balancing access logs in each region. Policies granting
+// access to these accounts are expected, so they are classified separately
+// from other accounts.
+//
+// Sources:
+// https://docs.aws.amazon.com/elasticloadbalancing/latest/application/enable-access-logging.html
+// https://docs.aws.amazon.com/redshift/latest/mgmt/db-auditing.html
+// https://docs.aws.amazon.com/awscloudtrail/latest/userguide/create-s3-bucket-policy-for-cloudtrail.html
+//
+// Newer regions use service principals instead, so this list only needs
+// updating if AWS publishes new account IDs.
+
+const (
+	wellKnownAccountSourceBuiltIn = "built_in"
+	wellKnownAccountSourceConfig  = "config"
+)
+
+type wellKnownAccount struct {
+	AccountId   string
+	Service     string
+	Region      string
+	Description string
+	Source      string
+}
+
+func builtInWellKnownAccounts() []wellKnownAccount {
+	accounts := []wellKnownAccount{}
+
+	add := func(service string, description string, accountsByRegion map[string]string) {
+		for region, accountId := range accountsByRegion {
+			accounts = append(accounts, wellKnownAccount{
+				AccountId:   accountId,
+				Service:     service,
+				Region:      region,
+				Description: description,
+				Source:      wellKnownAccountSourceBuiltIn,
+			})
+		}
+	}
+
+	add("elasticloadbalancing", "Elastic Load Balancing access log delivery", map[string]string{
+		"af-south-1":     "098369216593",
+		"ap-east-1":      "754344448648",
+		"ap-northeast-1": "582318560864",
+		"ap-northeast-2": "600734575887",
+		"ap-northeast-3": "383597477331",
+		"ap-south-1":     "718504428378",
+		"ap-southeast-1": "114774131450",
+		"ap-southeast-2": "783225319266",
+		"ap-southeast-3": "589379963580",
+		"ca-central-1":   "985666609251",
+		"cn-north-1":     "638102146993",
+		"cn-northwest-1": "037604701340",
+		"eu-central-1":   "054676820928",
+		"eu-north-1":     "897822967062",
+		"eu-south-1":     "635631232127",
+		"eu-west-1":      "156460612806",
+		"eu-west-2":      "652711504416",
+		"eu-west-3":      "009996457667",
+		"me-south-1":     "076674570225",
+		"sa-east-1":      "507241528517",
+		"us-east-1":      "127311923021",
+		"us-east-2":      "033677994240",
+		"us-gov-east-1":  "190560391635",
+		"us-gov-west-1":  "048591011584",
+		"us-west-1":      "027434742980",
+		"us-west-2":      "797873946194",
+	})
+
+	add("redshift", "Amazon Redshift audit log delivery", map[string]string{
+		"ap-northeast-1": "404641285394",
+		"ap-northeast-2": "760740231472",
+		"ap-south-1":     "865932855811",
+		"ap-southeast-1": "361669875840",
+		"ap-southeast-2": "762762565011",
+		"ca-central-1":   "907379612154",
+		"eu-central-1":   "053454850223",
+		"eu-west-1":      "210876761215",
+		"eu-west-2":      "307160386991",
+		"eu-west-3":      "915173422425",
+		"sa-east-1":      "075028567923",
+		"us-east-1":      "193672423079",
+		"us-east-2":      "391106570357",
+		"us-west-1":      "262260360010",
+		"us-west-2":      "902366379725",
+	})
+
+	add("cloudtrail", "AWS CloudTrail log delivery (legacy, before the cloudtrail.amazonaws.com service principal)", map[string]string{
+		"ap-northeast-1": "216624486486",
+		"ap-southeast-1": "903692715234",
+		"ap-southeast-2": "284668455005",
+		"eu-central-1":   "035351147821",
+		"eu-west-1":      "859597730677",
+		"sa-east-1":      "814480443879",
+		"us-east-1":      "086441151436",
+		"us-east-2":      "475085895292",
+		"us-west-1":      "388731089494",
+		"us-west-2":      "113285607260",
+	})
+
+	return accounts
+}
+
+// getWellKnownAccounts returns the well-known public accounts for the
+// connection: the built-in accounts, with any accounts in the
+// "wellknown_accounts" config argument added or replacing them
+func getWellKnownAccounts(d *plugin.QueryData) []wellKnownAccount {
+	awsSpcConfig := GetConfig(d.Connection)
+
+	accounts := []wellKnownAccount{}
+	for _, account := range builtInWellKnownAccounts() {
+		if _, ok := awsSpcConfig.WellKnownAccounts[account.AccountId]; !ok {
+			accounts = append(accounts, account)
+		}
+	}
+	for accountId, description := range awsSpcConfig.WellKnownAccounts {
+		accounts = append(accounts, wellKnownAccount{
+			AccountId:   accountId,
+			Description: description,
+			Source:      wellKnownAccountSourceConfig,
+		})
+	}
+
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].AccountId < accounts[j].AccountId
+	})
+	return accounts
+}
+
+// getWellKnownAccountIds returns the set of well-known public account IDs for
+// the connection
+func getWellKnownAccountIds(d *plugin.QueryData) map[string]bool {
+	ids := map[string]bool{}
+	for _, account := range getWellKnownAccounts(d) {
+		ids[account.AccountId] = true
+	}
+	return ids
+}
@@ -0,0 +1,50 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+func TestBuiltInWellKnownAccounts(t *testing.T) {
+	seen := map[string]string{}
+	for _, account := range builtInWellKnownAccounts() {
+		if !policyeval.IsAccountID(account.AccountId) {
+			t.Errorf("%s %s: invalid account ID %q", account.Service, account.Region, account.AccountId)
+		}
+		if other, ok := seen[account.AccountId]; ok {
+			t.Errorf("%s %s: account ID %s is also used by %s", account.Service, account.Region, account.AccountId, other)
+		}
+		seen[account.AccountId] = account.Service + " " + account.Region
+	}
+}
+
+func TestGetWellKnownAccounts(t *testing.T) {
+	d := &plugin.QueryData{Connection: &plugin.Connection{
+		Name: "aws",
+		Config: awsConfig{WellKnownAccounts: map[string]string{
+			"127311923021": "Overridden",
+			"111122223333": "Security scanner vendor",
+		}},
+	}}
+
+	accounts := map[string]wellKnownAccount{}
+	for _, account := range getWellKnownAccounts(d) {
+		if _, ok := accounts[account.AccountId]; ok {
+			t.Errorf("account ID %s returned more than once", account.AccountId)
+		}
+		accounts[account.AccountId] = account
+	}
+
+	if got := accounts["127311923021"]; got.Source != wellKnownAccountSourceConfig || got.Description != "Overridden" {
+		t.Errorf("built-in account not replaced by config: %+v", got)
+	}
+	if got := accounts["111122223333"]; got.Source != wellKnownAccountSourceConfig {
+		t.Errorf("config account not added: %+v", got)
+	}
+	if got := accounts["797873946194"]; got.Source != wellKnownAccountSourceBuiltIn || got.Service != "elasticloadbalancing" {
+		t.Errorf("built-in account missing: %+v", got)
+	}
+}
@@ -0,0 +1,316 @@
+// Command policyeval analyzes IAM policy documents offline, with the same
+// engine the plugin uses at query time, e.g. in pre-commit hooks.
+//
+// Usage:
+//
+//	policyeval [flags] [file ...]
+//
+// Policies are read from the files, or from stdin if there are none or the
+// file is "-". URL encoded documents, as returned by the IAM API, are
+// unescaped first. The analysis of each policy is printed as JSON or as a
+// table.
+//
+// Actions are checked against the IAM permissions data built into the plugin.
+// Actions released by AWS after the plugin was built are only known when a
+// newer file in Parliament's iam_definition.json format is given with
+// -iam-definition.
+//
+// Trust policies are analyzed for the accounts given with -account-ids, or
+// for the account of the role given with -resource-arn.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/parliament"
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+type options struct {
+	format        string
+	resourceArn   string
+	trustPolicy   bool
+	accountIds    string
+	wellKnown     string
+	iamDefinition string
+	strict        bool
+}
+
+// evaluatedPolicy is the analysis of one policy document. Field names match
+// the keys of the plugin's JSON columns with the same analysis.
+type evaluatedPolicy struct {
+	Source                  string
+	IneffectiveStatementIds []string
+	GrantsFullIAMAccess     bool
+	GrantsAllActions        bool
+	Metrics                 policyeval.Metrics
+	Evaluation              policyeval.EvaluatedPolicy
+	Trust                   *policyeval.TrustPolicyAnalysis `json:",omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var opts options
+	flags := flag.NewFlagSet("policyeval", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.StringVar(&opts.format, "format", "json", "output format, json or table")
+	flags.StringVar(&opts.resourceArn, "resource-arn", "", "ARN of the resource the policies are attached to, for resource-based policies")
+	flags.BoolVar(&opts.trustPolicy, "trust", false, "analyze the policies as role trust policies")
+	flags.StringVar(&opts.accountIds, "account-ids", "", "comma separated accounts treated as self, e.g. the account that owns the resource and the other accounts of the organization; allowed accounts are classified against them, and for trust policies they default to the account of -resource-arn")
+	flags.StringVar(&opts.wellKnown, "wellknown-accounts", "", "comma separated AWS owned account IDs, classified as services rather than other accounts")
+	flags.StringVar(&opts.iamDefinition, "iam-definition", "", "path of an iam_definition.json file listing the known actions (default the plugin's built-in data)")
+	flags.BoolVar(&opts.strict, "strict", false, "exit with status 1 if any policy has ineffective statements")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if opts.format != "json" && opts.format != "table" {
+		fmt.Fprintf(stderr, "policyeval: invalid -format %q, expected json or table\n", opts.format)
+		return 2
+	}
+
+	// Without the owning account every principal of a trust policy would be
+	// treated as cross account
+	if opts.trustPolicy && opts.accountIds == "" {
+		if opts.accountIds = roleAccountID(opts.resourceArn); opts.accountIds == "" {
+			fmt.Fprintln(stderr, "policyeval: -trust requires -account-ids, or the role ARN as -resource-arn")
+			return 2
+		}
+	}
+
+	permissions := parliament.IamPermissions()
+	if opts.iamDefinition != "" {
+		var err error
+		if permissions, err = readPermissions(opts.iamDefinition); err != nil {
+			fmt.Fprintf(stderr, "policyeval: failed to read -iam-definition %q: %v\n", opts.iamDefinition, err)
+			return 1
+		}
+	}
+	actions := actionData{
+		known:    permissions.KnownActions(),
+		metadata: permissions.ActionMetadata(),
+	}
+	actions.expander = policyeval.NewActionExpander(actions.known)
+
+	sources := flags.Args()
+	if len(sources) == 0 {
+		sources = []string{"-"}
+	}
+
+	var results []evaluatedPolicy
+	for _, source := range sources {
+		document, err := readDocument(source, stdin)
+		if err != nil {
+			fmt.Fprintf(stderr, "policyeval: %s: %v\n", source, err)
+			return 1
+		}
+		result, err := evaluate(source, document, opts, actions)
+		if err != nil {
+			fmt.Fprintf(stderr, "policyeval: %s: %v\n", source, err)
+			return 1
+		}
+		results = append(results, result)
+	}
+
+	if opts.format == "table" {
+		writeTable(stdout, results, opts.trustPolicy)
+	} else {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(results); err != nil {
+			fmt.Fprintf(stderr, "policyeval: %v\n", err)
+			return 1
+		}
+	}
+
+	if opts.strict {
+		for _, result := range results {
+			if len(result.IneffectiveStatementIds) > 0 {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// actionData is the IAM permissions data policies are analyzed with
+type actionData struct {
+	known    policyeval.KnownActions
+	expander *policyeval.ActionExpander
+	metadata policyeval.ActionMetadata
+}
+
+// evaluate analyzes the policy document the same way the plugin's tables do
+func evaluate(source string, document string, opts options, actions actionData) (evaluatedPolicy, error) {
+	document = strings.TrimSpace(document)
+	if !strings.HasPrefix(document, "{") {
+		unescaped, err := url.QueryUnescape(document)
+		if err != nil {
+			return evaluatedPolicy{}, err
+		}
+		document = unescaped
+	}
+
+	policy, err := policyeval.Parse(document)
+	if err != nil {
+		return evaluatedPolicy{}, err
+	}
+
+	// S3 counts the bytes of a bucket policy against its quota, IAM the
+	// characters excluding whitespace
+	size, sizeQuota := policyeval.IAMPolicySize(document), policyeval.IAMManagedPolicySizeQuota
+	if arnParts := strings.Split(opts.resourceArn, ":"); len(arnParts) > 2 && arnParts[2] == "s3" {
+		size, sizeQuota = len(document), policyeval.S3BucketPolicySizeQuota
+	}
+
+	result := evaluatedPolicy{
+		Source:                  source,
+		IneffectiveStatementIds: policyeval.IneffectiveStatementIds(policy, opts.resourceArn, actions.known),
+		GrantsFullIAMAccess:     policyeval.GrantsAction(policy, "iam:*"),
+		GrantsAllActions:        policyeval.GrantsAction(policy, "*"),
+		Metrics:                 policyeval.PolicyMetrics(policy, size, sizeQuota, actions.expander),
+		Evaluation: policyeval.EvaluatePolicy(policy, policyeval.EvaluatePolicyOptions{
+			ResourceArn:         opts.resourceArn,
+			KnownActions:        actions.known,
+			SelfAccountIds:      accountIdSet(opts.accountIds),
+			WellKnownAccountIds: accountIdSet(opts.wellKnown),
+			ActionExpander:      actions.expander,
+			ActionMetadata:      actions.metadata,
+		}),
+	}
+
+	if opts.trustPolicy {
+		trust := policyeval.AnalyzeTrustPolicy(policy, policyeval.TrustPolicyOptions{
+			AccountIds:          splitAccountIds(opts.accountIds),
+			WellKnownAccountIds: accountIdSet(opts.wellKnown),
+		})
+		result.Trust = &trust
+	}
+
+	return result, nil
+}
+
+// roleAccountID returns the account ID of an IAM role ARN, or "" if arn is
+// not a role ARN
+func roleAccountID(arn string) string {
+	parts := strings.SplitN(arn, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] != "iam" || !strings.HasPrefix(parts[5], "role/") || !policyeval.IsAccountID(parts[4]) {
+		return ""
+	}
+	return parts[4]
+}
+
+// splitAccountIds splits a comma separated list of account IDs
+func splitAccountIds(list string) []string {
+	var accountIds []string
+	for _, accountID := range strings.Split(list, ",") {
+		if accountID = strings.TrimSpace(accountID); accountID != "" {
+			accountIds = append(accountIds, accountID)
+		}
+	}
+	return accountIds
+}
+
+// accountIdSet returns the accounts of a comma separated list as a set
+func accountIdSet(list string) map[string]bool {
+	accountIds := map[string]bool{}
+	for _, accountID := range splitAccountIds(list) {
+		accountIds[accountID] = true
+	}
+	return accountIds
+}
+
+func readDocument(source string, stdin io.Reader) (string, error) {
+	var content []byte
+	var err error
+	if source == "-" {
+		content, err = io.ReadAll(stdin)
+	} else {
+		content, err = os.ReadFile(source)
+	}
+	return string(content), err
+}
+
+// readPermissions reads the actions of each service, with their resource
+// types, from an iam_definition.json file
+func readPermissions(path string) (parliament.Permissions, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var services []struct {
+		Prefix     string `json:"prefix"`
+		Privileges []struct {
+			Privilege     string `json:"privilege"`
+			ResourceTypes []struct {
+				ConditionKeys []string `json:"condition_keys"`
+				ResourceType  string   `json:"resource_type"`
+			} `json:"resource_types"`
+		} `json:"privileges"`
+	}
+	if err := json.Unmarshal(content, &services); err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("file does not contain any services")
+	}
+
+	permissions := parliament.Permissions{}
+	for _, s := range services {
+		service := parliament.Service{Prefix: s.Prefix}
+		for _, p := range s.Privileges {
+			privilege := parliament.Privilege{Privilege: p.Privilege}
+			for _, rt := range p.ResourceTypes {
+				privilege.ResourceTypes = append(privilege.ResourceTypes, parliament.ResourceType{
+					ConditionKeys: rt.ConditionKeys,
+					ResourceType:  rt.ResourceType,
+				})
+			}
+			service.Privileges = append(service.Privileges, privilege)
+		}
+		permissions = append(permissions, service)
+	}
+	return permissions, nil
+}
+
+func writeTable(w io.Writer, results []evaluatedPolicy, trustPolicy bool) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := "SOURCE\tSTATEMENTS\tACTIONS\tWILDCARD STATEMENTS\tSIZE QUOTA %\tFULL IAM ACCESS\tPUBLIC\tINEFFECTIVE STATEMENTS"
+	if trustPolicy {
+		header += "\tCROSS ACCOUNT PRINCIPALS"
+	}
+	fmt.Fprintln(tw, header)
+
+	for _, result := range results {
+		// Actions are only counted with action data
+		actionCount := "-"
+		if result.Metrics.ActionCount != nil {
+			actionCount = fmt.Sprint(*result.Metrics.ActionCount)
+		}
+		row := fmt.Sprintf("%s\t%d\t%s\t%d\t%.1f\t%t\t%t\t%s",
+			result.Source,
+			result.Metrics.StatementCount,
+			actionCount,
+			result.Metrics.WildcardStatementCount,
+			result.Metrics.SizeQuotaPercent,
+			result.GrantsFullIAMAccess,
+			result.Evaluation.IsPublic,
+			strings.Join(result.IneffectiveStatementIds, ","),
+		)
+		if trustPolicy {
+			row += "\t" + strings.Join(result.Trust.CrossAccountPrincipals, ",")
+		}
+		fmt.Fprintln(tw, row)
+	}
+	tw.Flush()
+}
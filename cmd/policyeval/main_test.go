@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	// URL encoded, as returned by the IAM API
+	document := `%7B%22Version%22%3A%222012-10-17%22%2C%22Statement%22%3A%5B%7B%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22AWS%22%3A%5B%22arn%3Aaws%3Aiam%3A%3A111122223333%3Aroot%22%2C%22AROADBQP57FF2AEXAMPLE%22%5D%7D%2C%22Action%22%3A%22sts%3AAssumeRole%22%7D%2C%7B%22Sid%22%3A%22Gone%22%2C%22Effect%22%3A%22Allow%22%2C%22Principal%22%3A%7B%22AWS%22%3A%22AIDAJQABLZS4A3QDU576Q%22%7D%2C%22Action%22%3A%22sts%3AAssumeRole%22%7D%5D%7D`
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-trust", "-account-ids", "123456789012,444455556666", "-strict"}, strings.NewReader(document), &stdout, &stderr)
+	if code != 1 {
+		t.Fatalf("exit code = %d, want 1 for an ineffective statement, stderr: %s", code, stderr.String())
+	}
+
+	var results []evaluatedPolicy
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	result := results[0]
+	if want := []string{"Gone"}; !reflect.DeepEqual(result.IneffectiveStatementIds, want) {
+		t.Errorf("IneffectiveStatementIds = %v, want %v", result.IneffectiveStatementIds, want)
+	}
+	if want := []string{"arn:aws:iam::111122223333:root"}; result.Trust == nil || !reflect.DeepEqual(result.Trust.CrossAccountPrincipals, want) {
+		t.Errorf("Trust = %+v, want CrossAccountPrincipals %v", result.Trust, want)
+	}
+
+	if code := run([]string{"-format", "yaml"}, strings.NewReader(document), &stdout, &stderr); code != 2 {
+		t.Errorf("invalid format: exit code = %d, want 2", code)
+	}
+	if code := run(nil, strings.NewReader(`{"Statement":`), &stdout, &stderr); code != 1 {
+		t.Errorf("invalid policy: exit code = %d, want 1", code)
+	}
+}
+
+func TestRunTrustAccountFromRoleArn(t *testing.T) {
+	document := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/ci","arn:aws:iam::111122223333:root"]},"Action":"sts:AssumeRole"}]}`
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"-trust"}, strings.NewReader(document), &stdout, &stderr); code != 2 {
+		t.Errorf("trust policy without account: exit code = %d, want 2", code)
+	}
+
+	stdout.Reset()
+	code := run([]string{"-trust", "-resource-arn", "arn:aws:iam::123456789012:role/deploy"}, strings.NewReader(document), &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("exit code = %d, want 0, stderr: %s", code, stderr.String())
+	}
+	var results []evaluatedPolicy
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"arn:aws:iam::111122223333:root"}; len(results) != 1 || results[0].Trust == nil || !reflect.DeepEqual(results[0].Trust.CrossAccountPrincipals, want) {
+		t.Errorf("results = %+v, want CrossAccountPrincipals %v", results, want)
+	}
+}
+
+func TestRunBuiltInActions(t *testing.T) {
+	document := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:GetObjcet"],"Resource":"*"},{"Sid":"Typo","Effect":"Allow","Action":"s3:GetObjcet","Resource":"*"}]}`
+
+	var stdout, stderr bytes.Buffer
+	if code := run(nil, strings.NewReader(document), &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code = %d, want 0, stderr: %s", code, stderr.String())
+	}
+	var results []evaluatedPolicy
+	if err := json.Unmarshal(stdout.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1", len(results))
+	}
+	if want := []string{"Typo"}; !reflect.DeepEqual(results[0].IneffectiveStatementIds, want) {
+		t.Errorf("IneffectiveStatementIds = %v, want %v", results[0].IneffectiveStatementIds, want)
+	}
+	if count := results[0].Metrics.ActionCount; count == nil || *count != 1 {
+		t.Errorf("ActionCount = %v, want 1", count)
+	}
+}
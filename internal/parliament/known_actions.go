@@ -0,0 +1,52 @@
+// Package parliament holds the IAM permissions data generated from Parliament
+// (see scripts/generate_parliament_iam_permissions), shared by the plugin and
+// the policyeval command.
+package parliament
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/policyeval"
+)
+
+// KnownActions returns the actions of each service, in the lower case form
+// used to tell which actions in a policy exist
+func (permissions Permissions) KnownActions() policyeval.KnownActions {
+	actions := policyeval.KnownActions{}
+	for _, service := range permissions {
+		prefix := strings.ToLower(service.Prefix)
+		for _, privilege := range service.Privileges {
+			actions[prefix] = append(actions[prefix], strings.ToLower(privilege.Privilege))
+		}
+	}
+	return actions
+}
+
+// ActionMetadata returns whether each action supports resource-level
+// permissions, i.e. has a named resource type, and the condition keys of its
+// resource types
+func (permissions Permissions) ActionMetadata() policyeval.ActionMetadata {
+	metadata := policyeval.ActionMetadata{}
+	for _, service := range permissions {
+		prefix := strings.ToLower(service.Prefix)
+		for _, privilege := range service.Privileges {
+			info := policyeval.ActionInfo{ConditionKeys: []string{}}
+			seen := map[string]bool{}
+			for _, resourceType := range privilege.ResourceTypes {
+				if resourceType.ResourceType != "" {
+					info.SupportsResourceLevelPermissions = true
+				}
+				for _, key := range resourceType.ConditionKeys {
+					if !seen[key] {
+						seen[key] = true
+						info.ConditionKeys = append(info.ConditionKeys, key)
+					}
+				}
+			}
+			sort.Strings(info.ConditionKeys)
+			metadata[prefix+":"+strings.ToLower(privilege.Privilege)] = info
+		}
+	}
+	return metadata
+}
@@ -0,0 +1,116 @@
+package policyeval
+
+import (
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
+)
+
+// KnownActions maps each lower case service prefix to the lower case names of
+// its actions, e.g. "s3" -> ["getobject", "putobject", ...]
+type KnownActions map[string][]string
+
+// serviceAliases maps service prefixes that policies use, but that are not
+// the prefix IAM documents actions under, to the documented prefix. They are
+// older names or the service's endpoint name, e.g. "email" for SES.
+var serviceAliases = map[string]string{
+	"elb":        "elasticloadbalancing",
+	"email":      "ses",
+	"emr":        "elasticmapreduce",
+	"monitoring": "cloudwatch",
+	"opensearch": "es",
+	"pinpoint":   "mobiletargeting",
+}
+
+// canonicalServicePrefix returns the documented service prefix for an alias,
+// or prefix itself
+func canonicalServicePrefix(prefix string) string {
+	if canonical, ok := serviceAliases[prefix]; ok {
+		return canonical
+	}
+	return prefix
+}
+
+// Matches reports whether the lower case action pattern, which may contain
+// wildcards, matches at least one known action. Service prefix aliases, e.g.
+// "email" for "ses", match the actions of the service.
+func (actions KnownActions) Matches(pattern string) bool {
+	if pattern == "*" {
+		return true
+	}
+	prefix, name, found := strings.Cut(pattern, ":")
+	if !found {
+		return false
+	}
+
+	// Wildcards in the service prefix are unusual, so only then check every
+	// service
+	if strings.ContainsAny(prefix, "*?") {
+		for servicePrefix, names := range actions {
+			if wildcard.Match(prefix, servicePrefix) && matchesAnyName(name, names) {
+				return true
+			}
+		}
+		return false
+	}
+	return matchesAnyName(name, actions[canonicalServicePrefix(prefix)])
+}
+
+func matchesAnyName(pattern string, names []string) bool {
+	for _, name := range names {
+		if wildcard.Match(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// GrantsAction reports whether any Allow statement in the policy grants
+// action (which may itself be a pattern such as iam:*) on all resources.
+// Conditions are not evaluated, so a conditional grant still counts.
+func GrantsAction(policy Policy, action string) bool {
+	for _, statement := range policy.Statements {
+		if statement.Effect == "Allow" && StatementCoversAction(statement, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// DeniesAction reports whether any unconditional Deny statement in the policy
+// denies action on all resources.
+func DeniesAction(policy Policy, action string) bool {
+	for _, statement := range policy.Statements {
+		if statement.Effect == "Deny" && len(statement.Condition) == 0 && StatementCoversAction(statement, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// StatementCoversAction reports whether the statement applies to every
+// action matched by action on every resource.
+func StatementCoversAction(statement Statement, action string) bool {
+	if len(statement.NotResource) == 0 && !wildcard.MatchAny(statement.Resource, "*") {
+		return false
+	}
+	return StatementMatchesAction(statement, action)
+}
+
+// StatementMatchesAction reports whether the statement's Action or NotAction
+// applies to every action matched by action, ignoring resources. Actions are
+// lower case in canonical form.
+func StatementMatchesAction(statement Statement, action string) bool {
+	if len(statement.NotAction) > 0 {
+		// The statement covers action unless one of the excluded actions
+		// overlaps it, e.g. NotAction iam:CreateUser excludes part of iam:*
+		for _, pattern := range statement.NotAction {
+			if wildcard.Match(pattern, action) || wildcard.Match(action, pattern) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return wildcard.MatchAny(statement.Action, action)
+}
@@ -0,0 +1,67 @@
+package policyeval
+
+// ActionMetadata maps each known action, as lower case "prefix:name", to what
+// the IAM documentation says about it
+type ActionMetadata map[string]ActionInfo
+
+// ActionInfo is the IAM documentation of an action
+type ActionInfo struct {
+	// SupportsResourceLevelPermissions is false if the action can only be
+	// granted on "Resource": "*"
+	SupportsResourceLevelPermissions bool
+	// ConditionKeys are the condition keys the action supports, as documented
+	ConditionKeys []string
+}
+
+// StatementActions describes the actions granted by an Allow statement
+type StatementActions struct {
+	StatementId string
+	// WildcardResource is "mandatory" if the statement grants its actions on
+	// "Resource": "*" and none of them support resource-level permissions,
+	// "lazy" if some of them could be scoped to specific resources instead,
+	// and empty if the statement names its resources
+	WildcardResource string
+	// ConditionKeys are the condition keys supported by any of the granted
+	// actions
+	ConditionKeys []string
+}
+
+// statementActions describes the known actions the statement grants.
+// Actions missing from metadata are left out.
+func statementActions(statement Statement, id string, expander *ActionExpander, metadata ActionMetadata) StatementActions {
+	var actions []string
+	if len(statement.NotAction) > 0 {
+		actions = expander.ExpandNot(statement.NotAction)
+	} else {
+		for _, pattern := range statement.Action {
+			actions = append(actions, expander.Expand(pattern)...)
+		}
+	}
+
+	known := false
+	resourceLevel := false
+	conditionKeys := map[string]bool{}
+	for _, action := range actions {
+		info, ok := metadata[action]
+		if !ok {
+			continue
+		}
+		known = true
+		resourceLevel = resourceLevel || info.SupportsResourceLevelPermissions
+		for _, key := range info.ConditionKeys {
+			conditionKeys[key] = true
+		}
+	}
+
+	result := StatementActions{
+		StatementId:   id,
+		ConditionKeys: appendSortedKeys([]string{}, conditionKeys),
+	}
+	if known && len(statement.NotResource) == 0 && containsString(statement.Resource, "*") {
+		result.WildcardResource = "mandatory"
+		if resourceLevel {
+			result.WildcardResource = "lazy"
+		}
+	}
+	return result
+}
@@ -0,0 +1,49 @@
+package policyeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEvaluatePolicyStatements(t *testing.T) {
+	knownActions := KnownActions{
+		"s3":  {"getobject", "listallmybuckets"},
+		"ec2": {"describeinstances"},
+	}
+	metadata := ActionMetadata{
+		"s3:getobject":          {SupportsResourceLevelPermissions: true, ConditionKeys: []string{"s3:ExistingObjectTag/${TagKey}", "s3:VersionId"}},
+		"s3:listallmybuckets":   {ConditionKeys: []string{}},
+		"ec2:describeinstances": {ConditionKeys: []string{"ec2:Region"}},
+	}
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Sid":"Lazy","Effect":"Allow","Principal":"*","Action":["s3:GetObject","s3:ListAllMyBuckets"],"Resource":"*"},
+		{"Sid":"Mandatory","Effect":"Allow","Principal":"*","Action":["s3:ListAllMyBuckets","ec2:Describe*"],"Resource":"*"},
+		{"Sid":"Scoped","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/*"},
+		{"Sid":"NotAction","Effect":"Allow","Principal":"*","NotAction":"s3:*","Resource":"*"},
+		{"Sid":"Unknown","Effect":"Allow","Principal":"*","Action":"s3:GetObjekt","Resource":"*"},
+		{"Sid":"Deny","Effect":"Deny","Principal":"*","Action":"s3:GetObject","Resource":"*"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{
+		KnownActions:   knownActions,
+		ActionExpander: NewActionExpander(knownActions),
+		ActionMetadata: metadata,
+	})
+	want := []StatementActions{
+		{StatementId: "Lazy", WildcardResource: "lazy", ConditionKeys: []string{"s3:ExistingObjectTag/${TagKey}", "s3:VersionId"}},
+		{StatementId: "Mandatory", WildcardResource: "mandatory", ConditionKeys: []string{"ec2:Region"}},
+		{StatementId: "Scoped", ConditionKeys: []string{"s3:ExistingObjectTag/${TagKey}", "s3:VersionId"}},
+		{StatementId: "NotAction", WildcardResource: "mandatory", ConditionKeys: []string{"ec2:Region"}},
+	}
+	if !reflect.DeepEqual(got.Statements, want) {
+		t.Errorf("Statements = %+v, want %+v", got.Statements, want)
+	}
+
+	// Without action data statements are not described
+	if got := EvaluatePolicy(policy, EvaluatePolicyOptions{}); len(got.Statements) != 0 {
+		t.Errorf("Statements without action data = %+v, want none", got.Statements)
+	}
+}
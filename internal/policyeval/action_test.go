@@ -0,0 +1,108 @@
+package policyeval
+
+import (
+	"testing"
+)
+
+func TestPolicyGrantsAndDeniesIamWildcard(t *testing.T) {
+	cases := []struct {
+		name      string
+		policy    string
+		grants    bool
+		denies    bool
+		grantsAll bool
+	}{
+		{
+			name:      "admin",
+			policy:    `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"*","Resource":"*"}}`,
+			grants:    true,
+			grantsAll: true,
+		},
+		{
+			name:   "iam full access",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["IAM:*","s3:GetObject"],"Resource":"*"}]}`,
+			grants: true,
+		},
+		{
+			name:   "iam read only",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["iam:Get*","iam:List*"],"Resource":"*"}]}`,
+		},
+		{
+			name:   "iam scoped to a path",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"iam:*","Resource":"arn:aws:iam::123456789012:role/app/*"}]}`,
+		},
+		{
+			name:   "not action excluding other services",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":"s3:*","Resource":"*"}]}`,
+			grants: true,
+		},
+		{
+			name:   "not action excluding part of iam",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","NotAction":["iam:CreateUser"],"Resource":"*"}]}`,
+		},
+		{
+			name:      "admin with iam denied",
+			policy:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"iam:*","Resource":"*"}]}`,
+			grants:    true,
+			denies:    true,
+			grantsAll: true,
+		},
+		{
+			name:      "conditional deny",
+			policy:    `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"*","Resource":"*"},{"Effect":"Deny","Action":"iam:*","Resource":"*","Condition":{"Bool":{"aws:MultiFactorAuthPresent":"false"}}}]}`,
+			grants:    true,
+			grantsAll: true,
+		},
+	}
+
+	for _, c := range cases {
+		policy, err := Parse(c.policy)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := GrantsAction(policy, "iam:*"); got != c.grants {
+			t.Errorf("%s: GrantsAction(iam:*) = %v, want %v", c.name, got, c.grants)
+		}
+		if got := DeniesAction(policy, "iam:*"); got != c.denies {
+			t.Errorf("%s: DeniesAction(iam:*) = %v, want %v", c.name, got, c.denies)
+		}
+		if got := GrantsAction(policy, "*"); got != c.grantsAll {
+			t.Errorf("%s: GrantsAction(*) = %v, want %v", c.name, got, c.grantsAll)
+		}
+	}
+}
+
+func TestKnownActionsMatches(t *testing.T) {
+	actions := KnownActions{
+		"ses":                  {"sendemail", "sendrawemail"},
+		"s3-object-lambda":     {"getobject"},
+		"execute-api":          {"invoke", "managetoconnections"},
+		"elasticloadbalancing": {"describeloadbalancers"},
+		"mobiletargeting":      {"getapps"},
+	}
+
+	cases := []struct {
+		pattern string
+		want    bool
+	}{
+		{"*", true},
+		{"ses:sendemail", true},
+		{"s3-object-lambda:getobject", true},
+		{"execute-api:invoke", true},
+		{"execute-api:*", true},
+		{"*:invoke", true},
+		// Aliases match the actions of the documented prefix
+		{"email:sendemail", true},
+		{"email:send*", true},
+		{"elb:describeloadbalancers", true},
+		{"pinpoint:getapps", true},
+		{"email:deleteidentity", false},
+		{"s3:getobject", false},
+		{"invalid", false},
+	}
+	for _, c := range cases {
+		if got := actions.Matches(c.pattern); got != c.want {
+			t.Errorf("Matches(%q) = %v, want %v", c.pattern, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package policyeval
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Condition is a single condition key of a statement. Keys are lower case in
+// canonical form.
+type Condition struct {
+	Sid      string `json:"Sid,omitempty"`
+	Operator string
+	Key      string
+	Values   interface{}
+}
+
+// StatementConditions returns the conditions of the statement whose key
+// starts with one of the lower case prefixes, sorted by key and operator.
+func StatementConditions(statement Statement, keyPrefixes []string) []Condition {
+	var conditions []Condition
+
+	for operator, condition := range statement.Condition {
+		for key, values := range condition.(map[string]interface{}) {
+			for _, prefix := range keyPrefixes {
+				if strings.HasPrefix(key, prefix) {
+					conditions = append(conditions, Condition{
+						Sid:      statement.Sid,
+						Operator: operator,
+						Key:      key,
+						Values:   values,
+					})
+					break
+				}
+			}
+		}
+	}
+
+	// Map iteration order is random, sort so the result is stable
+	sort.Slice(conditions, func(i, j int) bool {
+		if conditions[i].Key != conditions[j].Key {
+			return conditions[i].Key < conditions[j].Key
+		}
+		return conditions[i].Operator < conditions[j].Operator
+	})
+
+	return conditions
+}
+
+// ConditionKeyCount returns the number of condition keys of the statement,
+// across all operators.
+func ConditionKeyCount(statement Statement) int {
+	count := 0
+	for _, condition := range statement.Condition {
+		count += len(condition.(map[string]interface{}))
+	}
+	return count
+}
+
+// DeniesInsecureTransport reports whether the policy has a Deny statement for
+// all principals covering action, whose only condition is aws:SecureTransport
+// being false. Actions are lower case in canonical form.
+func DeniesInsecureTransport(policy Policy, action string) bool {
+	for _, statement := range policy.Statements {
+		if statement.Effect != "Deny" || !StatementMatchesAction(statement, action) {
+			continue
+		}
+		if !containsString(AWSPrincipals(statement), "*") || ConditionKeyCount(statement) != 1 {
+			continue
+		}
+
+		for operator, condition := range statement.Condition {
+			values, ok := condition.(map[string]interface{})["aws:securetransport"]
+			if ok && (operator == "Bool" || operator == "BoolIfExists") && reflect.DeepEqual(values, []string{"false"}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Condition keys that limit the principals or networks a statement applies
+// to, so that a statement for all principals is not public. Keys are lower
+// case in canonical form.
+var principalConditionKeys = map[string]bool{
+	"aws:principalaccount":  true,
+	"aws:principalarn":      true,
+	"aws:principalorgid":    true,
+	"aws:principalorgpaths": true,
+	"aws:sourceaccount":     true,
+	"aws:sourcearn":         true,
+	"aws:sourceip":          true,
+	"aws:sourceorgid":       true,
+	"aws:sourceorgpaths":    true,
+	"aws:sourceowner":       true,
+	"aws:sourcevpc":         true,
+	"aws:sourcevpce":        true,
+	"aws:userid":            true,
+	"aws:username":          true,
+}
+
+// Condition operators that match requests whose key has one of the values
+var positiveConditionOperators = map[string]bool{
+	"ArnEquals":              true,
+	"ArnLike":                true,
+	"IpAddress":              true,
+	"StringEquals":           true,
+	"StringEqualsIgnoreCase": true,
+	"StringLike":             true,
+}
+
+// Condition operators that match requests whose key has none of the values
+var negatedConditionOperators = map[string]bool{
+	"ArnNotEquals":              true,
+	"ArnNotLike":                true,
+	"NotIpAddress":              true,
+	"StringNotEquals":           true,
+	"StringNotEqualsIgnoreCase": true,
+	"StringNotLike":             true,
+}
+
+// Principal and network condition keys that are in the context of every
+// request a resource policy is evaluated for. aws:SourceIp is only missing
+// from requests made through a VPC endpoint or by an AWS service. The other
+// keys are missing from anonymous requests, or from requests not made on
+// behalf of another resource.
+var alwaysPresentConditionKeys = map[string]bool{
+	"aws:sourceip": true,
+}
+
+// principalConditions describes how the conditions of a statement limit the
+// requests it applies to
+type principalConditions struct {
+	// Limited is true if a condition only matches some principals or networks
+	Limited bool
+	// MatchesNothing is true if a condition excludes every request, e.g.
+	// NotIpAddress 0.0.0.0/0
+	MatchesNothing bool
+	// Exclusions are the negated conditions, which exclude some principals or
+	// networks but match everyone else
+	Exclusions []Condition
+}
+
+// statementPrincipalConditions classifies the conditions of the statement on
+// principal and network keys. All the conditions of a statement must match,
+// so one limiting condition limits the statement, and one that excludes every
+// request makes it match nothing. A condition matches if the key has any of
+// its values, so a condition is only limiting if none of its values matches
+// every request.
+func statementPrincipalConditions(statement Statement) principalConditions {
+	var result principalConditions
+
+	for _, condition := range StatementConditions(statement, []string{"aws:"}) {
+		if !principalConditionKeys[condition.Key] {
+			continue
+		}
+		operator, matchesMissingKey := splitConditionOperator(condition.Operator)
+		values, _ := condition.Values.([]string)
+
+		matchesAll := false
+		for _, value := range values {
+			if conditionValueMatchesAll(operator, value) {
+				matchesAll = true
+			}
+		}
+
+		switch {
+		case positiveConditionOperators[operator]:
+			// Requests without the key, e.g. without aws:SourceVpce from the
+			// internet, match IfExists conditions
+			if !matchesAll && !matchesMissingKey {
+				result.Limited = true
+			}
+		case negatedConditionOperators[operator]:
+			// A request must match none of the values, so a value matching
+			// every request excludes them all. Negated operators match requests
+			// without the key, e.g. anonymous requests without
+			// aws:PrincipalOrgID, so only keys every request has can exclude
+			// every request.
+			if matchesAll && !matchesMissingKey && alwaysPresentConditionKeys[condition.Key] {
+				result.MatchesNothing = true
+			}
+			result.Exclusions = append(result.Exclusions, condition)
+		}
+	}
+
+	return result
+}
+
+// splitConditionOperator returns the operator without its IfExists suffix
+// and ForAnyValue: or ForAllValues: qualifier, and whether the condition
+// matches requests that do not have the key. ForAllValues matches a missing
+// key, as there are no values that fail to match.
+func splitConditionOperator(operator string) (string, bool) {
+	base := strings.TrimSuffix(operator, "IfExists")
+	matchesMissingKey := base != operator
+
+	if strings.HasPrefix(base, "ForAllValues:") {
+		matchesMissingKey = true
+	}
+	base = strings.TrimPrefix(base, "ForAllValues:")
+	base = strings.TrimPrefix(base, "ForAnyValue:")
+
+	return base, matchesMissingKey
+}
+
+// conditionValueMatchesAll reports whether the value of a condition with the
+// operator matches every request, e.g. IpAddress 0.0.0.0/0 or StringLike *
+func conditionValueMatchesAll(operator string, value string) bool {
+	switch {
+	case strings.HasSuffix(operator, "IpAddress"):
+		return value == "0.0.0.0/0" || value == "::/0"
+	case strings.HasSuffix(operator, "Like"):
+		return strings.Trim(value, "*") == ""
+	}
+	return false
+}
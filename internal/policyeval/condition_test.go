@@ -0,0 +1,39 @@
+package policyeval
+
+import "testing"
+
+func TestDeniesInsecureTransport(t *testing.T) {
+	cases := []struct {
+		name   string
+		policy string
+		want   bool
+	}{
+		{
+			name:   "deny without tls",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"elasticfilesystem:*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`,
+			want:   true,
+		},
+		{
+			name:   "deny for one principal",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"elasticfilesystem:ClientMount","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`,
+		},
+		{
+			name:   "deny with another condition",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"*","Condition":{"Bool":{"aws:SecureTransport":"false"},"StringEquals":{"aws:SourceVpc":"vpc-1234"}}}]}`,
+		},
+		{
+			name:   "allow with tls",
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"elasticfilesystem:ClientMount","Condition":{"Bool":{"aws:SecureTransport":"true"}}}]}`,
+		},
+	}
+
+	for _, c := range cases {
+		policy, err := Parse(c.policy)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := DeniesInsecureTransport(policy, "elasticfilesystem:clientmount"); got != c.want {
+			t.Errorf("%s: DeniesInsecureTransport = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
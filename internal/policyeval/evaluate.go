@@ -0,0 +1,216 @@
+package policyeval
+
+// EvaluatedPolicy describes who the Allow statements of a policy grant access
+// to. Deny statements are only evaluated to find policies that deny
+// everything to everyone.
+type EvaluatedPolicy struct {
+	// IsPublic is true if a statement allows all principals and none of its
+	// conditions limit the principals or networks it applies to. Negated
+	// conditions, e.g. StringNotEquals aws:PrincipalOrgID, only exclude some
+	// principals, so do not stop a statement being public.
+	IsPublic bool
+	// PublicAccessOverriddenBy are the Block Public Access settings, e.g.
+	// BucketRestrictPublicBuckets, that stop a public policy being in effect.
+	// IsPublic is false when any are listed.
+	PublicAccessOverriddenBy []string
+	// AllowedPrincipals are all the principals allowed by the policy, with
+	// service principals in canonical form
+	AllowedPrincipals                   []string
+	AllowedPrincipalAccountIds          []string
+	AllowedPrincipalServices            []string
+	AllowedPrincipalFederatedIdentities []string
+	// AccountClassifications maps each allowed account to "private" if it is
+	// one of EvaluatePolicyOptions.SelfAccountIds, "service" if it is one of
+	// the WellKnownAccountIds, or "shared" if neither. It is empty unless
+	// SelfAccountIds is set.
+	AccountClassifications map[string]string
+	// PrincipalSources maps each allowed principal to the statements that
+	// allow it, as principals are listed once however many statements allow
+	// them
+	PrincipalSources map[string][]string
+	// ConditionExclusions are the negated conditions on principal and network
+	// keys, e.g. NotIpAddress aws:SourceIp, of the Allow statements
+	ConditionExclusions []Condition
+	// DeletedPrincipalIds are the unique IDs, e.g. AIDAJQABLZS4A3QDU576Q, that
+	// IAM put in place of deleted users and roles. They allow nothing, so are
+	// not listed as allowed principals.
+	DeletedPrincipalIds []string
+	// IneffectiveStatementIds are the Allow statements left out of the
+	// evaluation because they cannot allow anything, see
+	// IneffectiveStatementIds
+	IneffectiveStatementIds []string
+	// Statements describe the actions granted by each evaluated Allow
+	// statement, when EvaluatePolicyOptions has the action data
+	Statements []StatementActions
+}
+
+// EvaluatePolicyOptions describes the context a policy is evaluated in
+type EvaluatePolicyOptions struct {
+	// ResourceArn is the resource a resource-based policy is attached to.
+	// Statements whose Resource names a different resource, e.g. another
+	// bucket, grant nothing on this one. Leave empty for other policies.
+	ResourceArn string
+	// KnownActions, if set, leaves out statements none of whose actions exist
+	KnownActions KnownActions
+	// PublicAccessBlock is the S3 Block Public Access configuration that
+	// applies to the resource, if any
+	PublicAccessBlock PublicAccessBlock
+	// SelfAccountIds are the accounts whose principals are private, e.g. the
+	// account that owns the resource and the other accounts of its
+	// organization
+	SelfAccountIds map[string]bool
+	// WellKnownAccountIds are AWS owned accounts, such as service log
+	// delivery accounts, which are classified as "service" rather than
+	// "shared"
+	WellKnownAccountIds map[string]bool
+	// ActionExpander and ActionMetadata, if both set, describe the actions
+	// each statement grants in EvaluatedPolicy.Statements
+	ActionExpander *ActionExpander
+	ActionMetadata ActionMetadata
+}
+
+// PublicAccessBlock holds the S3 Block Public Access settings that decide
+// whether a public bucket policy is in effect. RestrictPublicBuckets, set on
+// the bucket or on its account, limits access under a public policy to AWS
+// service principals and users in the bucket owner's account.
+type PublicAccessBlock struct {
+	BucketRestrictPublicBuckets  bool
+	AccountRestrictPublicBuckets bool
+}
+
+// overriddenBy lists the settings that stop a public policy being in effect
+func (block PublicAccessBlock) overriddenBy() []string {
+	controls := []string{}
+	if block.BucketRestrictPublicBuckets {
+		controls = append(controls, "BucketRestrictPublicBuckets")
+	}
+	if block.AccountRestrictPublicBuckets {
+		controls = append(controls, "AccountRestrictPublicBuckets")
+	}
+	return controls
+}
+
+// EvaluatePolicy classifies the principals allowed by a policy in canonical
+// form. Service principals are normalized with NormalizeServicePrincipal, so
+// the regional and canonical forms of a service are listed once. Statements
+// that cannot allow anything, e.g. because their Resource does not match
+// opts.ResourceArn, or because a negated condition excludes every request,
+// are listed in IneffectiveStatementIds and their principals are not counted.
+// A policy that denies every action to everyone, e.g. while a bucket is
+// locked down, allows nothing, so its evaluation is empty. A public policy is
+// not in effect if opts.PublicAccessBlock restricts public buckets, so
+// IsPublic is false and the settings are listed in PublicAccessOverriddenBy.
+func EvaluatePolicy(policy Policy, opts EvaluatePolicyOptions) EvaluatedPolicy {
+	principals := map[string]bool{}
+	accountIds := map[string]bool{}
+	services := map[string]bool{}
+	federated := map[string]bool{}
+	deleted := map[string]bool{}
+	evaluated := EvaluatedPolicy{
+		PublicAccessOverriddenBy: []string{},
+		AccountClassifications:   map[string]string{},
+		PrincipalSources:         map[string][]string{},
+		ConditionExclusions:      []Condition{},
+		IneffectiveStatementIds:  []string{},
+		Statements:               []StatementActions{},
+	}
+	if deniesEveryone(policy) {
+		evaluated.AllowedPrincipals = []string{}
+		evaluated.AllowedPrincipalAccountIds = []string{}
+		evaluated.AllowedPrincipalServices = []string{}
+		evaluated.AllowedPrincipalFederatedIdentities = []string{}
+		evaluated.DeletedPrincipalIds = []string{}
+		return evaluated
+	}
+
+	for i, statement := range policy.Statements {
+		if statement.Effect != "Allow" {
+			continue
+		}
+		conditions := statementPrincipalConditions(statement)
+		if conditions.MatchesNothing || statementIsIneffective(statement, opts.ResourceArn, opts.KnownActions) {
+			evaluated.IneffectiveStatementIds = append(evaluated.IneffectiveStatementIds, statementId(statement, i))
+			continue
+		}
+		evaluated.ConditionExclusions = append(evaluated.ConditionExclusions, conditions.Exclusions...)
+		if containsString(AWSPrincipals(statement), "*") && !conditions.Limited {
+			evaluated.IsPublic = true
+		}
+		if opts.ActionExpander != nil && opts.ActionMetadata != nil {
+			evaluated.Statements = append(evaluated.Statements, statementActions(statement, statementId(statement, i), opts.ActionExpander, opts.ActionMetadata))
+		}
+
+		for principalType, values := range statement.Principal {
+			values, _ := values.([]string)
+			for _, principal := range values {
+				switch principalType {
+				case "AWS":
+					// A statement whose principals are all deleted is
+					// ineffective, a mix still allows the others
+					if IsDeletedPrincipalID(principal) {
+						deleted[principal] = true
+						continue
+					}
+					if accountID := PrincipalAccountID(principal); accountID != "" {
+						accountIds[accountID] = true
+					}
+				case "Service":
+					principal = NormalizeServicePrincipal(principal)
+					services[principal] = true
+				case "Federated":
+					federated[principal] = true
+				}
+				principals[principal] = true
+
+				// A statement can list a principal more than once, e.g. in
+				// regional and canonical form
+				id := statementId(statement, i)
+				if sources := evaluated.PrincipalSources[principal]; len(sources) == 0 || sources[len(sources)-1] != id {
+					evaluated.PrincipalSources[principal] = append(sources, id)
+				}
+			}
+		}
+	}
+
+	evaluated.AllowedPrincipals = appendSortedKeys([]string{}, principals)
+	evaluated.AllowedPrincipalAccountIds = appendSortedKeys([]string{}, accountIds)
+	evaluated.AllowedPrincipalServices = appendSortedKeys([]string{}, services)
+	evaluated.AllowedPrincipalFederatedIdentities = appendSortedKeys([]string{}, federated)
+	evaluated.DeletedPrincipalIds = appendSortedKeys([]string{}, deleted)
+	if len(opts.SelfAccountIds) > 0 {
+		for accountID := range accountIds {
+			evaluated.AccountClassifications[accountID] = classifyAccount(accountID, opts)
+		}
+	}
+	if evaluated.IsPublic {
+		evaluated.PublicAccessOverriddenBy = opts.PublicAccessBlock.overriddenBy()
+		evaluated.IsPublic = len(evaluated.PublicAccessOverriddenBy) == 0
+	}
+	return evaluated
+}
+
+// classifyAccount returns whether the principals of an allowed account are
+// private to the resource owner, an AWS service, or shared with another
+// account
+func classifyAccount(accountID string, opts EvaluatePolicyOptions) string {
+	switch {
+	case opts.SelfAccountIds[accountID]:
+		return "private"
+	case opts.WellKnownAccountIds[accountID]:
+		return "service"
+	default:
+		return "shared"
+	}
+}
+
+// deniesEveryone reports whether an unconditional Deny statement for all
+// principals covers every action on every resource, which no Allow overrides.
+func deniesEveryone(policy Policy) bool {
+	for _, statement := range policy.Statements {
+		if statement.Effect == "Deny" && len(statement.Condition) == 0 &&
+			containsString(AWSPrincipals(statement), "*") && StatementCoversAction(statement, "*") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,389 @@
+package policyeval
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Principal":{"Service":["logs.us-east-1.amazonaws.com","logs.amazonaws.com"]},"Action":"s3:PutObject","Resource":"*"},
+		{"Effect":"Allow","Principal":{"Service":"logs.ap-east-1.amazonaws.com","AWS":["arn:aws:iam::111122223333:root","444455556666"]},"Action":"s3:GetObject","Resource":"*"},
+		{"Effect":"Allow","Principal":{"Federated":"cognito-identity.amazonaws.com"},"Action":"s3:GetObject","Resource":"*"},
+		{"Effect":"Deny","Principal":{"Service":"ec2.amazonaws.com"},"Action":"s3:*","Resource":"*"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{})
+	want := EvaluatedPolicy{
+		PublicAccessOverriddenBy:            []string{},
+		AccountClassifications:              map[string]string{},
+		AllowedPrincipals:                   []string{"444455556666", "arn:aws:iam::111122223333:root", "cognito-identity.amazonaws.com", "logs.amazonaws.com"},
+		AllowedPrincipalAccountIds:          []string{"111122223333", "444455556666"},
+		AllowedPrincipalServices:            []string{"logs.amazonaws.com"},
+		AllowedPrincipalFederatedIdentities: []string{"cognito-identity.amazonaws.com"},
+		PrincipalSources: map[string][]string{
+			"444455556666":                   {"2"},
+			"arn:aws:iam::111122223333:root": {"2"},
+			"cognito-identity.amazonaws.com": {"3"},
+			"logs.amazonaws.com":             {"1", "2"},
+		},
+		ConditionExclusions:     []Condition{},
+		DeletedPrincipalIds:     []string{},
+		IneffectiveStatementIds: []string{},
+		Statements:              []StatementActions{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluatePolicy = %+v, want %+v", got, want)
+	}
+}
+
+func TestEvaluatePolicyDeletedPrincipals(t *testing.T) {
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Sid":"Mixed","Effect":"Allow","Principal":{"AWS":["AIDAJQABLZS4A3QDU576Q","arn:aws:iam::111122223333:root"]},"Action":"s3:GetObject","Resource":"*"},
+		{"Sid":"Gone","Effect":"Allow","Principal":{"AWS":"AROADBQP57FF2AEXAMPLE"},"Action":"s3:GetObject","Resource":"*"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{})
+	if want := []string{"arn:aws:iam::111122223333:root"}; !reflect.DeepEqual(got.AllowedPrincipals, want) {
+		t.Errorf("AllowedPrincipals = %q, want %q", got.AllowedPrincipals, want)
+	}
+	if want := map[string][]string{"arn:aws:iam::111122223333:root": {"Mixed"}}; !reflect.DeepEqual(got.PrincipalSources, want) {
+		t.Errorf("PrincipalSources = %q, want %q", got.PrincipalSources, want)
+	}
+	if want := []string{"AIDAJQABLZS4A3QDU576Q"}; !reflect.DeepEqual(got.DeletedPrincipalIds, want) {
+		t.Errorf("DeletedPrincipalIds = %q, want %q", got.DeletedPrincipalIds, want)
+	}
+	// A statement for deleted principals only allows nothing
+	if want := []string{"Gone"}; !reflect.DeepEqual(got.IneffectiveStatementIds, want) {
+		t.Errorf("IneffectiveStatementIds = %q, want %q", got.IneffectiveStatementIds, want)
+	}
+}
+
+func TestEvaluatePolicyDenyEveryone(t *testing.T) {
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Sid":"Public","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"},
+		{"Sid":"Lockdown","Effect":"Deny","Principal":"*","Action":"*","Resource":"*"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{})
+	want := EvaluatedPolicy{
+		PublicAccessOverriddenBy:            []string{},
+		AccountClassifications:              map[string]string{},
+		AllowedPrincipals:                   []string{},
+		AllowedPrincipalAccountIds:          []string{},
+		AllowedPrincipalServices:            []string{},
+		AllowedPrincipalFederatedIdentities: []string{},
+		PrincipalSources:                    map[string][]string{},
+		ConditionExclusions:                 []Condition{},
+		DeletedPrincipalIds:                 []string{},
+		IneffectiveStatementIds:             []string{},
+		Statements:                          []StatementActions{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("EvaluatePolicy = %+v, want %+v", got, want)
+	}
+
+	// A conditional or partial deny leaves the Allow statements in place
+	for _, deny := range []string{
+		`{"Effect":"Deny","Principal":"*","Action":"*","Resource":"*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}`,
+		`{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"*"}`,
+		`{"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::111122223333:root"},"Action":"*","Resource":"*"}`,
+	} {
+		policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+			{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"},` + deny + `]}`)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := EvaluatePolicy(policy, EvaluatePolicyOptions{}); !got.IsPublic {
+			t.Errorf("EvaluatePolicy with %s: IsPublic = false, want true", deny)
+		}
+	}
+}
+
+func TestEvaluatePolicyPublicAccessBlock(t *testing.T) {
+	public, err := Parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	private, err := Parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":"111122223333"},"Action":"s3:GetObject","Resource":"*"}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name         string
+		policy       Policy
+		block        PublicAccessBlock
+		isPublic     bool
+		overriddenBy []string
+	}{
+		{"no block", public, PublicAccessBlock{}, true, []string{}},
+		{"bucket block", public, PublicAccessBlock{BucketRestrictPublicBuckets: true}, false, []string{"BucketRestrictPublicBuckets"}},
+		{"account block", public, PublicAccessBlock{AccountRestrictPublicBuckets: true}, false, []string{"AccountRestrictPublicBuckets"}},
+		{"both", public, PublicAccessBlock{BucketRestrictPublicBuckets: true, AccountRestrictPublicBuckets: true}, false, []string{"BucketRestrictPublicBuckets", "AccountRestrictPublicBuckets"}},
+		// A block only overrides a policy that would otherwise be public
+		{"private policy", private, PublicAccessBlock{BucketRestrictPublicBuckets: true}, false, []string{}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := EvaluatePolicy(c.policy, EvaluatePolicyOptions{PublicAccessBlock: c.block})
+			if got.IsPublic != c.isPublic {
+				t.Errorf("IsPublic = %v, want %v", got.IsPublic, c.isPublic)
+			}
+			if !reflect.DeepEqual(got.PublicAccessOverriddenBy, c.overriddenBy) {
+				t.Errorf("PublicAccessOverriddenBy = %q, want %q", got.PublicAccessOverriddenBy, c.overriddenBy)
+			}
+		})
+	}
+}
+
+func TestEvaluatePolicyAccountClassifications(t *testing.T) {
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::111122223333:root","arn:aws:iam::444455556666:role/reader","777788889999"]},"Action":"s3:GetObject","Resource":"*"},
+		{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::127311923021:root"},"Action":"s3:PutObject","Resource":"*"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Any of several accounts, e.g. of one organization, count as self, and
+	// AWS owned accounts, e.g. ELB log delivery, are services
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{
+		SelfAccountIds:      map[string]bool{"111122223333": true, "444455556666": true},
+		WellKnownAccountIds: map[string]bool{"127311923021": true},
+	})
+	want := map[string]string{
+		"111122223333": "private",
+		"127311923021": "service",
+		"444455556666": "private",
+		"777788889999": "shared",
+	}
+	if !reflect.DeepEqual(got.AccountClassifications, want) {
+		t.Errorf("AccountClassifications = %v, want %v", got.AccountClassifications, want)
+	}
+
+	// Without self accounts there is nothing to classify against
+	if got := EvaluatePolicy(policy, EvaluatePolicyOptions{}); len(got.AccountClassifications) != 0 {
+		t.Errorf("AccountClassifications without SelfAccountIds = %v, want none", got.AccountClassifications)
+	}
+}
+
+func TestEvaluatePolicyResourceArn(t *testing.T) {
+	// A common bucket policy mistake: the statement names another bucket
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[
+		{"Sid":"OtherBucket","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket-backup/*"},
+		{"Sid":"Logs","Effect":"Allow","Principal":{"Service":"logging.s3.amazonaws.com"},"Action":"s3:PutObject","Resource":"arn:aws:s3:::my-bucket/logs/*"},
+		{"Effect":"Allow","Principal":{"AWS":"111122223333"},"Action":"s3:ListBucket","Resource":"arn:aws:s3:::my-bucket"}
+	]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := EvaluatePolicy(policy, EvaluatePolicyOptions{ResourceArn: "arn:aws:s3:::my-bucket"})
+	if want := []string{"111122223333", "logging.s3.amazonaws.com"}; !reflect.DeepEqual(got.AllowedPrincipals, want) {
+		t.Errorf("AllowedPrincipals = %q, want %q", got.AllowedPrincipals, want)
+	}
+	if want := []string{"OtherBucket"}; !reflect.DeepEqual(got.IneffectiveStatementIds, want) {
+		t.Errorf("IneffectiveStatementIds = %q, want %q", got.IneffectiveStatementIds, want)
+	}
+
+	// Without the resource every statement counts
+	got = EvaluatePolicy(policy, EvaluatePolicyOptions{})
+	if want := []string{"*", "111122223333", "logging.s3.amazonaws.com"}; !reflect.DeepEqual(got.AllowedPrincipals, want) {
+		t.Errorf("AllowedPrincipals without resource = %q, want %q", got.AllowedPrincipals, want)
+	}
+}
+
+func TestEvaluatePolicyNegatedConditions(t *testing.T) {
+	cases := []struct {
+		name        string
+		statement   string
+		public      bool
+		exclusions  int
+		ineffective []string
+	}{
+		{
+			name:      "no condition",
+			statement: `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*"}`,
+			public:    true,
+		},
+		{
+			name:      "org only",
+			statement: `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}}`,
+		},
+		{
+			// Everyone outside the organization
+			name:       "not org",
+			statement:  `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringNotEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"}}}`,
+			public:     true,
+			exclusions: 1,
+		},
+		{
+			name:       "not from topic",
+			statement:  `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"ArnNotLike":{"aws:SourceArn":"arn:aws:sns:*:123456789012:*"}}}`,
+			public:     true,
+			exclusions: 1,
+		},
+		{
+			name:        "not any address",
+			statement:   `{"Sid":"Nobody","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"NotIpAddress":{"aws:SourceIp":["0.0.0.0/0","10.0.0.0/8"]}}}`,
+			ineffective: []string{"Nobody"},
+		},
+		{
+			// Requests without an organization, e.g. anonymous requests, have
+			// no aws:PrincipalOrgID and match
+			name:       "not any org",
+			statement:  `{"Sid":"S","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"StringNotLike":{"aws:PrincipalOrgID":"*"}}}`,
+			public:     true,
+			exclusions: 1,
+		},
+		{
+			name:       "not any source arn",
+			statement:  `{"Sid":"S","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"ArnNotLike":{"aws:SourceArn":"*"}}}`,
+			public:     true,
+			exclusions: 1,
+		},
+		{
+			name:       "not one network",
+			statement:  `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"NotIpAddress":{"aws:SourceIp":"10.0.0.0/8"}}}`,
+			public:     true,
+			exclusions: 1,
+		},
+		{
+			name:      "unrelated key",
+			statement: `{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"Bool":{"aws:SecureTransport":"true"}}}`,
+			public:    true,
+		},
+	}
+
+	for _, c := range cases {
+		policy, err := Parse(`{"Version":"2012-10-17","Statement":[` + c.statement + `]}`)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		got := EvaluatePolicy(policy, EvaluatePolicyOptions{})
+		if got.IsPublic != c.public {
+			t.Errorf("%s: IsPublic = %v, want %v", c.name, got.IsPublic, c.public)
+		}
+		if len(got.ConditionExclusions) != c.exclusions {
+			t.Errorf("%s: ConditionExclusions = %+v, want %d", c.name, got.ConditionExclusions, c.exclusions)
+		}
+		if c.ineffective == nil {
+			c.ineffective = []string{}
+		}
+		if !reflect.DeepEqual(got.IneffectiveStatementIds, c.ineffective) {
+			t.Errorf("%s: IneffectiveStatementIds = %q, want %q", c.name, got.IneffectiveStatementIds, c.ineffective)
+		}
+	}
+}
+
+func TestEvaluatePolicyCompositeConditions(t *testing.T) {
+	cases := []struct {
+		name      string
+		condition string
+		public    bool
+	}{
+		{
+			// Both must match, and either limits the statement
+			name:      "source arn and vpc endpoint",
+			condition: `{"ArnLike":{"aws:SourceArn":"arn:aws:sns:us-east-1:123456789012:*"},"StringEquals":{"aws:SourceVpce":"vpce-1a2b3c4d"}}`,
+		},
+		{
+			name:      "org and any address",
+			condition: `{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"},"IpAddress":{"aws:SourceIp":"0.0.0.0/0"}}`,
+		},
+		{
+			// Any of the values matches, and one of them matches everyone
+			name:      "address values include any address",
+			condition: `{"IpAddress":{"aws:SourceIp":["203.0.113.0/24","0.0.0.0/0"]}}`,
+			public:    true,
+		},
+		{
+			name:      "address values",
+			condition: `{"IpAddress":{"aws:SourceIp":["203.0.113.0/24","198.51.100.0/24"]}}`,
+		},
+		{
+			name:      "wildcard source arn",
+			condition: `{"ArnLike":{"aws:SourceArn":"*"}}`,
+			public:    true,
+		},
+		{
+			name:      "any address and unrelated key",
+			condition: `{"IpAddress":{"aws:SourceIp":"0.0.0.0/0"},"Bool":{"aws:SecureTransport":"true"}}`,
+			public:    true,
+		},
+		{
+			// Requests from the internet have no aws:SourceVpce
+			name:      "vpc endpoint if exists",
+			condition: `{"StringEqualsIfExists":{"aws:SourceVpce":"vpce-1a2b3c4d"}}`,
+			public:    true,
+		},
+		{
+			name:      "org and vpc endpoint if exists",
+			condition: `{"StringEquals":{"aws:PrincipalOrgID":"o-a1b2c3d4e5"},"StringEqualsIfExists":{"aws:SourceVpce":"vpce-1a2b3c4d"}}`,
+		},
+		{
+			name:      "any org path",
+			condition: `{"ForAnyValue:StringLike":{"aws:PrincipalOrgPaths":"o-a1b2c3d4e5/r-ab12/ou-ab12-11111111/*"}}`,
+		},
+		{
+			name:      "all org paths",
+			condition: `{"ForAllValues:StringLike":{"aws:PrincipalOrgPaths":"o-a1b2c3d4e5/r-ab12/ou-ab12-11111111/*"}}`,
+			public:    true,
+		},
+	}
+
+	for _, c := range cases {
+		policy, err := Parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":` + c.condition + `}]}`)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := EvaluatePolicy(policy, EvaluatePolicyOptions{}); got.IsPublic != c.public {
+			t.Errorf("%s: IsPublic = %v, want %v", c.name, got.IsPublic, c.public)
+		}
+	}
+
+	// NotIpAddress 0.0.0.0/0 only excludes every request when the key exists
+	policy, err := Parse(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"*","Condition":{"NotIpAddressIfExists":{"aws:SourceIp":"0.0.0.0/0"}}}]}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := EvaluatePolicy(policy, EvaluatePolicyOptions{}); !got.IsPublic || len(got.IneffectiveStatementIds) != 0 {
+		t.Errorf("NotIpAddressIfExists: got %+v, want public and effective", got)
+	}
+}
+
+func BenchmarkEvaluatePolicy(b *testing.B) {
+	statements := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		statements = append(statements, fmt.Sprintf(`{"Sid":"Read%d","Effect":"Allow","Principal":{"AWS":"arn:aws:iam::%012d:root"},"Action":["s3:GetObject","s3:ListBucket"],"Resource":"*","Condition":{"StringNotEquals":{"aws:PrincipalOrgID":"o-exampleorgid"}}}`, i, i))
+	}
+	document := `{"Version":"2012-10-17","Statement":[` + strings.Join(statements, ",") + `]}`
+	lockdown := `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"*","Resource":"*"},` + strings.Join(statements, ",") + `]}`
+
+	for _, benchmark := range []struct{ name, document string }{
+		{"allow", document},
+		{"deny everyone", lockdown},
+	} {
+		policy, err := Parse(benchmark.document)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(benchmark.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				EvaluatePolicy(policy, EvaluatePolicyOptions{ResourceArn: "arn:aws:s3:::my-bucket"})
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package policyeval
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
+)
+
+// IneffectiveStatementIds returns the statements of the policy that cannot
+// allow or deny anything, because:
+//   - none of their actions exist, e.g. a typo or an action AWS has removed
+//   - all of their principals are deleted users or roles
+//   - none of their resources match resourceArn, or anything under it, when the
+//     policy is attached to a resource
+//
+// Pass an empty resourceArn for policies that are not attached to a resource,
+// and nil knownActions to skip the check for actions that do not exist.
+//
+// A Deny statement with no matching Allow in the same policy is not reported.
+// An explicit Deny overrides an Allow in any policy that applies to the
+// request, e.g. an identity policy, a permissions boundary or an SCP, none of
+// which are visible here. A Deny that looks redundant within one policy is
+// often the guard rail the policy exists for.
+//
+// Statements are identified by Sid, or by their position in the policy
+// (starting at 1) if they have none.
+func IneffectiveStatementIds(policy Policy, resourceArn string, knownActions KnownActions) []string {
+	ids := []string{}
+
+	for i, statement := range policy.Statements {
+		if statementIsIneffective(statement, resourceArn, knownActions) {
+			ids = append(ids, statementId(statement, i))
+		}
+	}
+
+	return ids
+}
+
+// statementIsIneffective reports whether IneffectiveStatementIds would
+// report the statement
+func statementIsIneffective(statement Statement, resourceArn string, knownActions KnownActions) bool {
+	return statementHasOnlyUnknownActions(statement, knownActions) ||
+		statementHasOnlyDeletedPrincipals(statement) ||
+		(resourceArn != "" && !statementMatchesResource(statement, resourceArn))
+}
+
+// statementId returns the Sid of the statement at index i of a policy, or its
+// position (starting at 1) if it has none
+func statementId(statement Statement, i int) string {
+	if statement.Sid != "" {
+		return statement.Sid
+	}
+	return strconv.Itoa(i + 1)
+}
+
+// statementHasOnlyUnknownActions returns true if no action pattern in the
+// statement matches a known action. NotAction statements always match
+// something, so are never reported.
+func statementHasOnlyUnknownActions(statement Statement, knownActions KnownActions) bool {
+	if knownActions == nil || len(statement.Action) == 0 {
+		return false
+	}
+	for _, pattern := range statement.Action {
+		if knownActions.Matches(pattern) {
+			return false
+		}
+	}
+	return true
+}
+
+// statementHasOnlyDeletedPrincipals returns true if every principal of the
+// statement is the unique ID of a deleted user or role
+func statementHasOnlyDeletedPrincipals(statement Statement) bool {
+	if len(statement.Principal) != 1 {
+		return false
+	}
+	principals := AWSPrincipals(statement)
+	if len(principals) == 0 {
+		return false
+	}
+	for _, principal := range principals {
+		if !IsDeletedPrincipalID(principal) {
+			return false
+		}
+	}
+	return true
+}
+
+// statementMatchesResource returns true if a Resource of the statement
+// matches the ARN, or something under it such as the objects in a bucket.
+// Statements with no Resource, e.g. in trust policies, or with a NotResource
+// are treated as matching.
+func statementMatchesResource(statement Statement, resourceArn string) bool {
+	if len(statement.Resource) == 0 {
+		return true
+	}
+	for _, pattern := range statement.Resource {
+		if wildcard.Match(pattern, resourceArn) || wildcard.Match(pattern, resourceArn+"/") || strings.HasPrefix(pattern, resourceArn+"/") {
+			return true
+		}
+	}
+	return false
+}
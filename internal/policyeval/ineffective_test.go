@@ -0,0 +1,62 @@
+package policyeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicyIneffectiveStatementIds(t *testing.T) {
+	knownActions := KnownActions{
+		"s3":  {"getobject", "putobject", "listbucket"},
+		"sts": {"assumerole", "tagsession"},
+	}
+	bucketArn := "arn:aws:s3:::my-bucket"
+
+	cases := []struct {
+		name     string
+		policy   string
+		resource string
+		ids      []string
+	}{
+		{
+			name:     "effective",
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"Read","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket/*"},{"Effect":"Allow","Principal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:*","Resource":["arn:aws:s3:::my-bucket","arn:aws:s3:::my-bucket/logs/*"]}]}`,
+			resource: bucketArn,
+			ids:      []string{},
+		},
+		{
+			name:     "unknown actions",
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"Typo","Effect":"Allow","Principal":"*","Action":["s3:GetObjcet","s3:Put*"],"Resource":"*"},{"Effect":"Allow","Principal":"*","Action":["s3:GetObjcet","ec2:*"],"Resource":"*"},{"Effect":"Deny","Principal":"*","NotAction":"s3:Nothing","Resource":"*"}]}`,
+			resource: bucketArn,
+			ids:      []string{"2"},
+		},
+		{
+			name:     "deleted principals",
+			policy:   `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["AROADBQP57FF2AEXAMPLE","AIDAJQABLZS4A3QDU576Q"]},"Action":"sts:AssumeRole"},{"Effect":"Allow","Principal":{"AWS":["AROADBQP57FF2AEXAMPLE","arn:aws:iam::123456789012:root"]},"Action":"sts:AssumeRole"}]}`,
+			resource: "",
+			ids:      []string{"1"},
+		},
+		{
+			name:     "other resource",
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"Other","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-bucket-2/*"},{"Sid":"Wildcard","Effect":"Allow","Principal":"*","Action":"s3:GetObject","Resource":"arn:aws:s3:::my-*"}]}`,
+			resource: bucketArn,
+			ids:      []string{"Other"},
+		},
+		{
+			name:     "deny with no matching allow",
+			policy:   `{"Version":"2012-10-17","Statement":[{"Sid":"DenyInsecureTransport","Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::my-bucket/*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`,
+			resource: bucketArn,
+			ids:      []string{},
+		},
+	}
+
+	for _, c := range cases {
+		policy, err := Parse(c.policy)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if got := IneffectiveStatementIds(policy, c.resource, knownActions); !reflect.DeepEqual(got, c.ids) {
+			t.Errorf("%s: IneffectiveStatementIds = %q, want %q", c.name, got, c.ids)
+		}
+	}
+}
@@ -0,0 +1,207 @@
+package policyeval
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/turbot/steampipe-plugin-aws/internal/wildcard"
+)
+
+// Policy size quotas. IAM counts the characters of a managed policy excluding
+// whitespace, S3 counts the bytes of the whole bucket policy.
+// https://docs.aws.amazon.com/IAM/latest/UserGuide/reference_iam-quotas.html
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/access-policy-language-overview.html
+const (
+	IAMManagedPolicySizeQuota = 6144
+	S3BucketPolicySizeQuota   = 20480
+)
+
+// Metrics describes the size and complexity of a policy
+type Metrics struct {
+	StatementCount         int
+	ActionCount            *int
+	WildcardStatementCount int
+	Services               []string
+	ConditionKeyCount      int
+	Size                   int
+	SizeQuota              int
+	SizeQuotaPercent       float64
+}
+
+// PolicyMetrics measures the policy. Action patterns are expanded against the
+// known actions of the expander, so ActionCount is the number of distinct
+// actions the policy refers to, not the number of patterns. ActionCount and
+// Services are nil if expander is nil, as they cannot be known without the
+// action data. size is the policy size as counted against sizeQuota, e.g.
+// IAMPolicySize for IAM policies.
+func PolicyMetrics(policy Policy, size int, sizeQuota int, expander *ActionExpander) Metrics {
+	metrics := Metrics{
+		StatementCount: len(policy.Statements),
+		Size:           size,
+		SizeQuota:      sizeQuota,
+	}
+	if sizeQuota > 0 {
+		metrics.SizeQuotaPercent = float64(size) * 100 / float64(sizeQuota)
+	}
+
+	actions := map[string]bool{}
+
+	for _, statement := range policy.Statements {
+		patterns := statement.Action
+		if len(statement.NotAction) > 0 {
+			patterns = statement.NotAction
+		}
+		isWildcard := len(statement.NotAction) > 0
+		for _, pattern := range patterns {
+			if strings.ContainsAny(pattern, "*?") {
+				isWildcard = true
+			}
+		}
+		if isWildcard {
+			metrics.WildcardStatementCount++
+		}
+
+		if expander != nil {
+			var expanded []string
+			if len(statement.NotAction) > 0 {
+				expanded = expander.ExpandNot(statement.NotAction)
+			} else {
+				for _, pattern := range statement.Action {
+					expanded = append(expanded, expander.Expand(pattern)...)
+				}
+			}
+			for _, action := range expanded {
+				actions[action] = true
+			}
+		}
+
+		metrics.ConditionKeyCount += ConditionKeyCount(statement)
+	}
+
+	if expander != nil {
+		actionCount := len(actions)
+		metrics.ActionCount = &actionCount
+
+		services := map[string]bool{}
+		for action := range actions {
+			prefix, _, _ := strings.Cut(action, ":")
+			services[prefix] = true
+		}
+		metrics.Services = appendSortedKeys([]string{}, services)
+	}
+
+	return metrics
+}
+
+// ActionExpander expands action patterns to the known actions they match.
+// Matching a pattern walks every known action, so each distinct pattern is
+// only expanded once and the result kept for the life of the expander. Share
+// one expander between the policies of a connection. It is safe for
+// concurrent use.
+type ActionExpander struct {
+	knownActions KnownActions
+
+	mutex      sync.Mutex
+	expansions map[string][]string
+}
+
+// NewActionExpander returns an expander for the known actions
+func NewActionExpander(knownActions KnownActions) *ActionExpander {
+	return &ActionExpander{
+		knownActions: knownActions,
+		expansions:   map[string][]string{},
+	}
+}
+
+// Expand returns the known actions matched by the lower case action pattern,
+// as "prefix:name"
+func (expander *ActionExpander) Expand(pattern string) []string {
+	return expander.cached(pattern, func() []string {
+		return expander.expand([]string{pattern}, false)
+	})
+}
+
+// ExpandNot returns the known actions not matched by any of the lower case
+// action patterns, i.e. the actions of a NotAction statement
+func (expander *ActionExpander) ExpandNot(patterns []string) []string {
+	sorted := append([]string{}, patterns...)
+	sort.Strings(sorted)
+	// Action patterns cannot contain spaces, so the key cannot clash with a
+	// pattern passed to Expand
+	return expander.cached("not "+strings.Join(sorted, " "), func() []string {
+		return expander.expand(sorted, true)
+	})
+}
+
+func (expander *ActionExpander) cached(key string, expand func() []string) []string {
+	expander.mutex.Lock()
+	actions, ok := expander.expansions[key]
+	expander.mutex.Unlock()
+	if ok {
+		return actions
+	}
+
+	// Expand without holding the lock, at worst a pattern is expanded twice
+	actions = expand()
+	expander.mutex.Lock()
+	expander.expansions[key] = actions
+	expander.mutex.Unlock()
+	return actions
+}
+
+func (expander *ActionExpander) expand(patterns []string, not bool) []string {
+	actions := []string{}
+	for prefix, names := range expander.knownActions {
+		namePatterns := actionNamePatterns(patterns, prefix)
+		if len(namePatterns) == 0 && !not {
+			continue
+		}
+		for _, name := range names {
+			if matchesAnyActionName(namePatterns, name) != not {
+				actions = append(actions, prefix+":"+name)
+			}
+		}
+	}
+	return actions
+}
+
+// actionNamePatterns returns the name part of the action patterns that apply
+// to the service prefix, e.g. "get*" for "s3:Get*" and prefix "s3". Patterns
+// using an alias of the prefix, e.g. "email:Send*" for "ses", also apply.
+func actionNamePatterns(patterns []string, prefix string) []string {
+	var names []string
+	for _, pattern := range patterns {
+		if pattern == "*" {
+			names = append(names, "*")
+			continue
+		}
+		patternPrefix, name, found := strings.Cut(pattern, ":")
+		if found && (canonicalServicePrefix(patternPrefix) == prefix || (strings.ContainsAny(patternPrefix, "*?") && wildcard.Match(patternPrefix, prefix))) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func matchesAnyActionName(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == name || (strings.ContainsAny(pattern, "*?") && wildcard.Match(pattern, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// IAMPolicySize returns the size of the policy document as counted by IAM,
+// which ignores whitespace.
+func IAMPolicySize(document string) int {
+	size := 0
+	for _, r := range document {
+		if !unicode.IsSpace(r) {
+			size++
+		}
+	}
+	return size
+}
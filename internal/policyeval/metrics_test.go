@@ -0,0 +1,64 @@
+package policyeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPolicyMetrics(t *testing.T) {
+	knownActions := KnownActions{
+		"s3":  {"getobject", "putobject", "listbucket"},
+		"ec2": {"describeinstances", "runinstances"},
+		"iam": {"getrole"},
+	}
+	document := `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:Get*", "s3:ListBucket"], "Resource": "*", "Condition": {"StringEquals": {"aws:PrincipalTag/team": "a", "aws:ResourceTag/team": "a"}}},
+    {"Effect": "Allow", "Action": "ec2:DescribeInstances", "Resource": "*"},
+    {"Effect": "Deny", "NotAction": ["s3:*", "ec2:*"], "Resource": "*", "Condition": {"Bool": {"aws:SecureTransport": "false"}}}
+  ]
+}`
+
+	policy, err := Parse(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	size := IAMPolicySize(document)
+	expander := NewActionExpander(knownActions)
+	got := PolicyMetrics(policy, size, IAMManagedPolicySizeQuota, expander)
+
+	actionCount := 4
+	want := Metrics{
+		StatementCount:         3,
+		ActionCount:            &actionCount,
+		WildcardStatementCount: 2,
+		Services:               []string{"ec2", "iam", "s3"},
+		ConditionKeyCount:      3,
+		Size:                   size,
+		SizeQuota:              IAMManagedPolicySizeQuota,
+		SizeQuotaPercent:       float64(size) * 100 / IAMManagedPolicySizeQuota,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyMetrics = %+v, want %+v", got, want)
+	}
+
+	// The expansions are reused by later policies
+	if got := PolicyMetrics(policy, size, IAMManagedPolicySizeQuota, expander); !reflect.DeepEqual(got, want) {
+		t.Errorf("PolicyMetrics with cached expansions = %+v, want %+v", got, want)
+	}
+
+	// Without action data the actions of a policy are unknown
+	if got := PolicyMetrics(policy, size, IAMManagedPolicySizeQuota, nil); got.ActionCount != nil || got.Services != nil {
+		t.Errorf("PolicyMetrics without known actions: ActionCount = %v, Services = %q, want nil", got.ActionCount, got.Services)
+	}
+
+	// Service prefix aliases expand to the actions of the documented prefix
+	if got, want := NewActionExpander(KnownActions{"ses": {"sendemail"}}).Expand("email:send*"), []string{"ses:sendemail"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expand(email:send*) = %q, want %q", got, want)
+	}
+
+	if got := IAMPolicySize("{ \"a\" :\n\t\"b\" }"); got != 9 {
+		t.Errorf("IAMPolicySize = %d, want 9", got)
+	}
+}
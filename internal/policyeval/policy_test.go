@@ -1,4 +1,4 @@
-package aws
+package policyeval
 
 import (
 	"encoding/json"
@@ -123,7 +123,7 @@ func TestConvertPolicySortAndDups(t *testing.T) {
 			]
 		}`)
 
-	pol, err := canonicalPolicy(testCase)
+	pol, err := Parse(testCase)
 	if err != nil {
 		t.Errorf("Convert failed for case '%s': %v", pol, err)
 	}
@@ -147,7 +147,7 @@ func TestConvertPolicyWithSingleStatement(t *testing.T) {
 			}
 		}`)
 
-	pol, err := canonicalPolicy(testCase)
+	pol, err := Parse(testCase)
 	if err != nil {
 		t.Errorf("Convert failed for case '%s': %v", pol, err)
 	}
@@ -196,7 +196,7 @@ func TestConvertPolicyWithBoolsAndInts(t *testing.T) {
 			]
 		}`)
 
-	pol, err := canonicalPolicy(testCase)
+	pol, err := Parse(testCase)
 	if err != nil {
 		t.Errorf("Convert failed for case '%s': %v", pol, err)
 	}
@@ -253,7 +253,7 @@ func TestConvertPolicy(t *testing.T) {
 	for i, testCase := range cases {
 
 		t.Run(fmt.Sprint(i), func(t *testing.T) {
-			pol, err := canonicalPolicy(testCase)
+			pol, err := Parse(testCase)
 			if err != nil {
 				t.Errorf("Convert failed for case '%s': %v", pol, err)
 			}
@@ -271,3 +271,12 @@ func prettyPrint(src interface{}) {
 	fmt.Printf("\n %s\n", string(pretty))
 
 }
+
+func BenchmarkParse(b *testing.B) {
+	document := `{"Version":"2012-10-17","Statement":[{"Sid":"Read","Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","arn:aws:iam::111122223333:role/reader"]},"Action":["s3:GetObject","s3:ListBucket"],"Resource":["arn:aws:s3:::my-bucket","arn:aws:s3:::my-bucket/*"],"Condition":{"StringEquals":{"aws:PrincipalOrgID":"o-exampleorgid"}}},{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::my-bucket/*","Condition":{"Bool":{"aws:SecureTransport":"false"}}}]}`
+	for i := 0; i < b.N; i++ {
+		if _, err := Parse(document); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package policyeval
+
+import (
+	"regexp"
+	"strings"
+)
+
+var accountIdRegex = regexp.MustCompile(`^\d{12}$`)
+
+// IAM replaces the ARN of a deleted user or role in a policy with its unique
+// ID, e.g. AIDAJQABLZS4A3QDU576Q or AROADBQP57FF2AEXAMPLE
+var principalUniqueIdRegex = regexp.MustCompile(`^A(IDA|ROA)[A-Z0-9]+$`)
+
+// IsAccountID reports whether s is a 12 digit AWS account ID.
+func IsAccountID(s string) bool {
+	return accountIdRegex.MatchString(s)
+}
+
+// IsDeletedPrincipalID reports whether the principal is the unique ID of a
+// user or role, which IAM puts in place of the ARN when the user or role is
+// deleted.
+func IsDeletedPrincipalID(principal string) bool {
+	return principalUniqueIdRegex.MatchString(principal)
+}
+
+// ARNAccountID returns the account ID of the ARN, or "" if the ARN has none,
+// e.g. an S3 bucket ARN.
+func ARNAccountID(arn string) string {
+	if arnParts := strings.Split(arn, ":"); len(arnParts) > 4 {
+		return arnParts[4]
+	}
+	return ""
+}
+
+// PrincipalAccountID returns the account ID of an AWS principal, which may be
+// an account ID or an ARN. Returns "*" for the wildcard principal.
+func PrincipalAccountID(principal string) string {
+	if principal == "*" || IsAccountID(principal) {
+		return principal
+	}
+	return ARNAccountID(principal)
+}
+
+// AWSPrincipals returns the AWS principals of the statement. The "*"
+// principal is an AWS principal in canonical form.
+func AWSPrincipals(statement Statement) []string {
+	principals, ok := statement.Principal["AWS"].([]string)
+	if !ok {
+		return nil
+	}
+	return principals
+}
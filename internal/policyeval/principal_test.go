@@ -0,0 +1,26 @@
+package policyeval
+
+import "testing"
+
+func TestPrincipalAccountID(t *testing.T) {
+	tests := []struct {
+		principal string
+		want      string
+	}{
+		{"*", "*"},
+		{"123456789012", "123456789012"},
+		{"arn:aws:iam::123456789012:root", "123456789012"},
+		{"arn:aws-cn:iam::123456789012:role/path/name", "123456789012"},
+		{"AROADBQP57FF2AEXAMPLE", ""},
+		{"12345", ""},
+	}
+	for _, tt := range tests {
+		if got := PrincipalAccountID(tt.principal); got != tt.want {
+			t.Errorf("PrincipalAccountID(%q) = %q, want %q", tt.principal, got, tt.want)
+		}
+	}
+
+	if !IsDeletedPrincipalID("AIDAJQABLZS4A3QDU576Q") || IsDeletedPrincipalID("arn:aws:iam::123456789012:root") {
+		t.Error("IsDeletedPrincipalID did not tell unique IDs from ARNs")
+	}
+}
@@ -0,0 +1,36 @@
+package policyeval
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Some services are granted access with a regional service principal, e.g.
+// logs.us-east-1.amazonaws.com, and in opt-in regions such as ap-east-1 or
+// me-south-1 some services only accept the regional form. Regions are matched
+// by their shape rather than a fixed list, so new regions need no change.
+var regionalServicePrincipalRegex = regexp.MustCompile(`^([a-z0-9-]+(?:\.[a-z0-9-]+)*)\.[a-z]{2}(?:-gov|-iso[a-z]?)?-[a-z]+-\d+\.amazonaws\.com(?:\.cn)?$`)
+
+// servicePrincipalAliases maps service principals that name the same service
+// under a different spelling to the canonical principal. Principals are lower
+// case.
+var servicePrincipalAliases = map[string]string{
+	// Older roles in the China regions use principals with their own suffix
+	"ec2.amazonaws.com.cn":              "ec2.amazonaws.com",
+	"elasticmapreduce.amazonaws.com.cn": "elasticmapreduce.amazonaws.com",
+}
+
+// NormalizeServicePrincipal returns the canonical form of a service principal,
+// so that every spelling of a service is classified the same way, e.g.
+// Logs.us-east-1.amazonaws.com becomes logs.amazonaws.com. Principals that
+// are not service principals are returned lower case.
+func NormalizeServicePrincipal(principal string) string {
+	principal = strings.ToLower(principal)
+	if canonical, ok := servicePrincipalAliases[principal]; ok {
+		return canonical
+	}
+	if match := regionalServicePrincipalRegex.FindStringSubmatch(principal); match != nil {
+		return match[1] + ".amazonaws.com"
+	}
+	return principal
+}
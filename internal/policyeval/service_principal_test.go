@@ -0,0 +1,26 @@
+package policyeval
+
+import "testing"
+
+func TestNormalizeServicePrincipal(t *testing.T) {
+	tests := []struct {
+		principal string
+		want      string
+	}{
+		{"logs.amazonaws.com", "logs.amazonaws.com"},
+		{"logs.us-east-1.amazonaws.com", "logs.amazonaws.com"},
+		{"Logs.US-EAST-1.amazonaws.com", "logs.amazonaws.com"},
+		{"states.ap-east-1.amazonaws.com", "states.amazonaws.com"},
+		{"delivery.logs.me-south-1.amazonaws.com", "delivery.logs.amazonaws.com"},
+		{"logs.us-gov-west-1.amazonaws.com", "logs.amazonaws.com"},
+		{"logs.cn-north-1.amazonaws.com.cn", "logs.amazonaws.com"},
+		{"ec2.amazonaws.com.cn", "ec2.amazonaws.com"},
+		{"s3.dualstack.amazonaws.com", "s3.dualstack.amazonaws.com"},
+		{"accounts.google.com", "accounts.google.com"},
+	}
+	for _, tt := range tests {
+		if got := NormalizeServicePrincipal(tt.principal); got != tt.want {
+			t.Errorf("NormalizeServicePrincipal(%q) = %q, want %q", tt.principal, got, tt.want)
+		}
+	}
+}
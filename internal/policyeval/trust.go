@@ -0,0 +1,127 @@
+package policyeval
+
+import (
+	"sort"
+	"strings"
+)
+
+// Condition keys that control the tags and source identity a principal can
+// set on the session when assuming a role. Keys are lower case in canonical
+// form.
+var sessionConditionKeyPrefixes = []string{
+	"aws:requesttag/",
+	"aws:tagkeys",
+	"sts:transitivetagkeys",
+	"sts:sourceidentity",
+}
+
+// TrustPolicyAnalysis describes how a role's trust policy controls the
+// sessions created when the role is assumed, and who can assume it
+type TrustPolicyAnalysis struct {
+	AllowsTagSession        bool
+	AllowsSetSourceIdentity bool
+	// SessionTaggingEnforced is nil if the policy does not allow tagging
+	SessionTaggingEnforced *bool
+	SessionConditions      []Condition
+	CrossAccountPrincipals []string
+	WellKnownPrincipals    []string
+	DeletedPrincipalIds    []string
+	// IneffectiveStatementIds is not set by AnalyzeTrustPolicy, as it needs
+	// the known actions, see IneffectiveStatementIds
+	IneffectiveStatementIds []string
+}
+
+// TrustPolicyOptions describes the accounts a trust policy is analyzed
+// against
+type TrustPolicyOptions struct {
+	// AccountIds are the accounts treated as "self": the account that owns the
+	// role, and any others trusted the same way, e.g. the other accounts of an
+	// organization. Their principals are not cross-account principals.
+	AccountIds []string
+	// WellKnownAccountIds are AWS owned accounts, such as service log delivery
+	// accounts. Their principals are listed separately from other accounts.
+	WellKnownAccountIds map[string]bool
+}
+
+// AnalyzeTrustPolicy extracts the session tagging and source identity
+// allowances, and the principals of other accounts that can assume the role,
+// from a trust policy in canonical form. The unique IDs of deleted users and
+// roles are listed separately from the principals of other accounts.
+func AnalyzeTrustPolicy(policy Policy, opts TrustPolicyOptions) TrustPolicyAnalysis {
+	selfAccountIds := map[string]bool{}
+	for _, accountID := range opts.AccountIds {
+		selfAccountIds[accountID] = true
+	}
+
+	analysis := TrustPolicyAnalysis{
+		SessionConditions:      []Condition{},
+		CrossAccountPrincipals: []string{},
+		WellKnownPrincipals:    []string{},
+		DeletedPrincipalIds:    []string{},
+	}
+	enforced := true
+	crossAccount := map[string]bool{}
+	wellKnown := map[string]bool{}
+	deleted := map[string]bool{}
+
+	for _, statement := range policy.Statements {
+		if statement.Effect != "Allow" {
+			continue
+		}
+
+		conditions := StatementConditions(statement, sessionConditionKeyPrefixes)
+		analysis.SessionConditions = append(analysis.SessionConditions, conditions...)
+
+		if StatementMatchesAction(statement, "sts:tagsession") {
+			analysis.AllowsTagSession = true
+
+			// Tagging is only enforced if every statement allowing it constrains
+			// the tags that can be passed
+			hasTagCondition := false
+			for _, condition := range conditions {
+				if strings.HasPrefix(condition.Key, "aws:requesttag/") || condition.Key == "aws:tagkeys" {
+					hasTagCondition = true
+				}
+			}
+			enforced = enforced && hasTagCondition
+		}
+		if StatementMatchesAction(statement, "sts:setsourceidentity") {
+			analysis.AllowsSetSourceIdentity = true
+		}
+
+		if StatementMatchesAction(statement, "sts:assumerole") {
+			for _, principal := range AWSPrincipals(statement) {
+				if IsDeletedPrincipalID(principal) {
+					deleted[principal] = true
+					continue
+				}
+				principalAccount := PrincipalAccountID(principal)
+				if selfAccountIds[principalAccount] {
+					continue
+				}
+				if opts.WellKnownAccountIds[principalAccount] {
+					wellKnown[principal] = true
+				} else {
+					crossAccount[principal] = true
+				}
+			}
+		}
+	}
+
+	if analysis.AllowsTagSession {
+		analysis.SessionTaggingEnforced = &enforced
+	}
+	analysis.CrossAccountPrincipals = appendSortedKeys(analysis.CrossAccountPrincipals, crossAccount)
+	analysis.WellKnownPrincipals = appendSortedKeys(analysis.WellKnownPrincipals, wellKnown)
+	analysis.DeletedPrincipalIds = appendSortedKeys(analysis.DeletedPrincipalIds, deleted)
+
+	return analysis
+}
+
+func appendSortedKeys(dst []string, set map[string]bool) []string {
+	for key := range set {
+		dst = append(dst, key)
+	}
+	sort.Strings(dst)
+	return dst
+}
@@ -0,0 +1,50 @@
+package policyeval
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeTrustPolicyPrincipals(t *testing.T) {
+	document := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:role/local","111122223333","arn:aws:iam::127311923021:root","AROADBQP57FF2AEXAMPLE"]},"Action":"sts:AssumeRole"},{"Effect":"Deny","Principal":{"AWS":"arn:aws:iam::444455556666:root"},"Action":"sts:AssumeRole"}]}`
+	policy, err := Parse(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	analysis := AnalyzeTrustPolicy(policy, TrustPolicyOptions{
+		AccountIds:          []string{"123456789012"},
+		WellKnownAccountIds: map[string]bool{"127311923021": true},
+	})
+
+	if want := []string{"111122223333"}; !reflect.DeepEqual(analysis.CrossAccountPrincipals, want) {
+		t.Errorf("CrossAccountPrincipals = %v, want %v", analysis.CrossAccountPrincipals, want)
+	}
+	if want := []string{"arn:aws:iam::127311923021:root"}; !reflect.DeepEqual(analysis.WellKnownPrincipals, want) {
+		t.Errorf("WellKnownPrincipals = %v, want %v", analysis.WellKnownPrincipals, want)
+	}
+	if want := []string{"AROADBQP57FF2AEXAMPLE"}; !reflect.DeepEqual(analysis.DeletedPrincipalIds, want) {
+		t.Errorf("DeletedPrincipalIds = %v, want %v", analysis.DeletedPrincipalIds, want)
+	}
+}
+
+func TestAnalyzeTrustPolicyMultipleSelfAccounts(t *testing.T) {
+	document := `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Principal":{"AWS":["arn:aws:iam::123456789012:root","arn:aws:iam::111122223333:role/deploy","444455556666"]},"Action":"sts:AssumeRole"}}`
+	policy, err := Parse(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Principals in any of the self accounts, e.g. the accounts of an
+	// organization, are not cross-account
+	analysis := AnalyzeTrustPolicy(policy, TrustPolicyOptions{AccountIds: []string{"123456789012", "111122223333"}})
+	if want := []string{"444455556666"}; !reflect.DeepEqual(analysis.CrossAccountPrincipals, want) {
+		t.Errorf("CrossAccountPrincipals = %v, want %v", analysis.CrossAccountPrincipals, want)
+	}
+
+	// With no self accounts every principal is cross-account
+	analysis = AnalyzeTrustPolicy(policy, TrustPolicyOptions{})
+	if len(analysis.CrossAccountPrincipals) != 3 {
+		t.Errorf("CrossAccountPrincipals = %v, want all 3 principals", analysis.CrossAccountPrincipals)
+	}
+}
@@ -0,0 +1,158 @@
+// Package wildcard matches strings against patterns containing the "*" and
+// "?" wildcards, as used by IAM actions, ARNs, error codes and region names.
+//
+// "*" matches any sequence of characters (including none and including "/")
+// and "?" matches exactly one character. All other characters match
+// themselves. Compiled patterns are kept in an LRU cache shared by all
+// callers, so matching the same patterns over many rows only compiles each
+// pattern once.
+//
+// Patterns from the connection config, such as "regions", "ignore_error_codes"
+// and "redact_columns", have always used path.Match syntax, which also
+// supports character classes (e.g. "us-[ew]*") and "\" escapes. They are
+// matched with MatchGlob, which keeps that syntax.
+package wildcard
+
+import (
+	"container/list"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultCacheSize is the number of compiled patterns kept by the shared cache.
+const DefaultCacheSize = 1024
+
+var defaultCache = NewCache(DefaultCacheSize)
+
+// Match reports whether value matches pattern, using the shared cache.
+func Match(pattern, value string) bool {
+	return defaultCache.Match(pattern, value)
+}
+
+// MatchAny reports whether value matches any of the patterns, using the
+// shared cache.
+func MatchAny(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if defaultCache.Match(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchGlob reports whether value matches a connection config pattern.
+// Patterns with character classes or escapes are matched by path.Match, so
+// in them "*" does not match "/", which config values do not contain. Other
+// patterns are matched as by Match. Malformed patterns match nothing.
+func MatchGlob(pattern, value string) bool {
+	if strings.ContainsAny(pattern, `[\`) {
+		matched, err := path.Match(pattern, value)
+		return err == nil && matched
+	}
+	return defaultCache.Match(pattern, value)
+}
+
+// MatchAnyGlob reports whether value matches any of the connection config
+// patterns, see MatchGlob.
+func MatchAnyGlob(patterns []string, value string) bool {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidGlob reports whether a connection config pattern is well formed, i.e.
+// its character classes are closed.
+func ValidGlob(pattern string) bool {
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// Cache is an LRU cache of compiled patterns. It is safe for concurrent use.
+type Cache struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+}
+
+type cacheEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// NewCache returns a cache holding at most capacity compiled patterns. A
+// capacity less than 1 is treated as 1.
+func NewCache(capacity int) *Cache {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Cache{
+		capacity: capacity,
+		entries:  map[string]*list.Element{},
+		order:    list.New(),
+	}
+}
+
+// Match reports whether value matches pattern.
+func (c *Cache) Match(pattern, value string) bool {
+	// Patterns without wildcards don't need to be compiled
+	if !strings.ContainsAny(pattern, "*?") {
+		return pattern == value
+	}
+	return c.compiled(pattern).MatchString(value)
+}
+
+// Len returns the number of compiled patterns in the cache.
+func (c *Cache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+func (c *Cache) compiled(pattern string) *regexp.Regexp {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(element)
+		return element.Value.(*cacheEntry).re
+	}
+
+	re := compile(pattern)
+	c.entries[pattern] = c.order.PushFront(&cacheEntry{pattern: pattern, re: re})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).pattern)
+	}
+	return re
+}
+
+// compile translates a wildcard pattern into an anchored regular expression.
+// Every pattern is valid, since all characters other than the wildcards are
+// quoted.
+func compile(pattern string) *regexp.Regexp {
+	var expr strings.Builder
+	expr.WriteString(`(?s)^`)
+	for pattern != "" {
+		i := strings.IndexAny(pattern, "*?")
+		if i < 0 {
+			expr.WriteString(regexp.QuoteMeta(pattern))
+			break
+		}
+		expr.WriteString(regexp.QuoteMeta(pattern[:i]))
+		if pattern[i] == '*' {
+			expr.WriteString(`.*`)
+		} else {
+			expr.WriteString(`.`)
+		}
+		pattern = pattern[i+1:]
+	}
+	expr.WriteString(`$`)
+	return regexp.MustCompile(expr.String())
+}
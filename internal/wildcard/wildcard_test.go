@@ -0,0 +1,179 @@
+package wildcard
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"", "", true},
+		{"", "a", false},
+		{"*", "", true},
+		{"*", "anything/at:all", true},
+		{"AccessDenied", "AccessDenied", true},
+		{"AccessDenied", "AccessDeniedException", false},
+		{"AccessDenied*", "AccessDeniedException", true},
+		{"*Exception", "AccessDeniedException", true},
+		{"s3:Get*", "s3:GetObject", true},
+		{"s3:Get*", "s3:PutObject", false},
+		{"us-??st-1", "us-east-1", true},
+		{"us-??st-1", "us-west-1", true},
+		{"us-??st-1", "us-east-2", false},
+		{"us-*", "us-gov-west-1", true},
+		{"arn:aws:s3:::bucket/*", "arn:aws:s3:::bucket/path/to/object", true},
+		{"a?c", "abc", true},
+		{"a?c", "ac", false},
+		{"a?c", "aéc", true},
+		{"[abc]", "a", false},
+		{"[abc]", "[abc]", true},
+		{"a.b", "axb", false},
+		{"a+", "aa", false},
+		{"*a*b*", "xxaxxbxx", true},
+		{"*a*b*", "xxbxxaxx", false},
+		{"line1*", "line1\nline2", true},
+	}
+
+	for _, test := range tests {
+		if got := Match(test.pattern, test.value); got != test.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", test.pattern, test.value, got, test.want)
+		}
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	patterns := []string{"NoSuchEntity", "*NotFound*"}
+
+	if !MatchAny(patterns, "ResourceNotFoundException") {
+		t.Errorf("MatchAny(%q, %q) = false, want true", patterns, "ResourceNotFoundException")
+	}
+	if MatchAny(patterns, "AccessDenied") {
+		t.Errorf("MatchAny(%q, %q) = true, want false", patterns, "AccessDenied")
+	}
+	if MatchAny(nil, "AccessDenied") {
+		t.Errorf("MatchAny(nil, %q) = true, want false", "AccessDenied")
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	c := NewCache(2)
+
+	c.Match("a*", "a")
+	c.Match("b*", "b")
+	// Use a* so that b* is the least recently used pattern
+	c.Match("a*", "a")
+	c.Match("c*", "c")
+
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", c.Len())
+	}
+	if _, ok := c.entries["b*"]; ok {
+		t.Errorf("least recently used pattern b* was not evicted")
+	}
+	if _, ok := c.entries["a*"]; !ok {
+		t.Errorf("recently used pattern a* was evicted")
+	}
+
+	// Literal patterns are not compiled or cached
+	c.Match("literal", "literal")
+	if _, ok := c.entries["literal"]; ok {
+		t.Errorf("literal pattern was cached")
+	}
+}
+
+// matchReference is a straightforward backtracking implementation of the
+// wildcard semantics, used to check the compiled matcher.
+func matchReference(pattern, value string) bool {
+	if pattern == "" {
+		return value == ""
+	}
+	switch pattern[0] {
+	case '*':
+		for i := 0; i <= len(value); i++ {
+			if matchReference(pattern[1:], value[i:]) {
+				return true
+			}
+		}
+		return false
+	case '?':
+		if value == "" {
+			return false
+		}
+		_, size := utf8.DecodeRuneInString(value)
+		return matchReference(pattern[1:], value[size:])
+	default:
+		if value == "" || value[0] != pattern[0] {
+			return false
+		}
+		return matchReference(pattern[1:], value[1:])
+	}
+}
+
+func FuzzMatch(f *testing.F) {
+	f.Add("s3:Get*", "s3:GetObject")
+	f.Add("us-??st-1", "us-east-1")
+	f.Add("*a*b*", "xxaxxbxx")
+	f.Add("[a-z]+.*", "abc")
+	f.Add("a?c", "aéc")
+	f.Add(`\*`, `\x`)
+
+	c := NewCache(8)
+	f.Fuzz(func(t *testing.T, pattern, value string) {
+		// The reference matcher is exponential on many consecutive "*"s and
+		// treats "?" as a rune, so only compare on short valid UTF-8 input
+		if len(pattern) > 32 || len(value) > 64 || !utf8.ValidString(pattern) || !utf8.ValidString(value) {
+			return
+		}
+		want := matchReference(pattern, value)
+		if got := c.Match(pattern, value); got != want {
+			t.Errorf("Match(%q, %q) = %v, want %v", pattern, value, got, want)
+		}
+		if !c.Match(pattern, pattern) && !containsWildcard(pattern) {
+			t.Errorf("Match(%q, %q) = false for a literal pattern", pattern, pattern)
+		}
+	})
+}
+
+func containsWildcard(s string) bool {
+	for _, r := range s {
+		if r == '*' || r == '?' {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		want    bool
+	}{
+		{"us-*", "us-east-1", true},
+		{"us-[ew]*", "us-east-1", true},
+		{"us-[ew]*", "us-gov-west-1", false},
+		{"us-[^g]*", "us-west-2", true},
+		{"*Throttl[ei]*", "ThrottlingException", true},
+		{"aws_lambda_function.environment_*", "aws_lambda_function.environment_variables", true},
+		{"user_data", "user_data", true},
+		{`\*`, "*", true},
+		{"us-[", "us-[", false},
+	}
+
+	for _, test := range tests {
+		if got := MatchGlob(test.pattern, test.value); got != test.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", test.pattern, test.value, got, test.want)
+		}
+	}
+
+	if !MatchAnyGlob([]string{"NoSuchEntity", "*NotFound[A-Z]*"}, "ResourceNotFoundException") {
+		t.Errorf("MatchAnyGlob did not match a character class")
+	}
+	if ValidGlob("us-[") || !ValidGlob("us-[ew]*") {
+		t.Errorf("ValidGlob did not tell malformed patterns from valid ones")
+	}
+}
@@ -7,10 +7,10 @@ def escape_string(input_string):
 
 def write_conditions(conditions, go_file):
     if len(conditions) == 0:
-        go_file.write("""Conditions: []ParliamentCondition{},
+        go_file.write("""Conditions: []Condition{},
 """)
     else:
-        go_file.write("""Conditions: []ParliamentCondition{
+        go_file.write("""Conditions: []Condition{
 """)
         for condition in conditions:
             go_file.write("""{
@@ -57,10 +57,10 @@ def write_resource_type_dependent_actions(dependent_actions, go_file):
 
 def write_resource_types(resource_types, go_file):
     if len(resource_types) == 0:
-        go_file.write("""ResourceTypes: []ParliamentResourceType{},
+        go_file.write("""ResourceTypes: []ResourceType{},
 """)
     else:
-        go_file.write("""ResourceTypes: []ParliamentResourceType{
+        go_file.write("""ResourceTypes: []ResourceType{
 """)
         for resource_type in resource_types:
             go_file.write("""{
@@ -77,10 +77,10 @@ def write_resource_types(resource_types, go_file):
 
 def write_privileges(privileges, go_file):
     if len(privileges) == 0:
-        go_file.write("""Privileges: []ParliamentPrivilege{},
+        go_file.write("""Privileges: []Privilege{},
 """)
     else:
-        go_file.write("""Privileges: []ParliamentPrivilege{
+        go_file.write("""Privileges: []Privilege{
 """)
         for privilege in privileges:
             go_file.write("""{
@@ -100,10 +100,10 @@ def write_privileges(privileges, go_file):
 
 def write_resources(resources, go_file):
     if len(resources) == 0:
-        go_file.write("""Resources: []ParliamentResource{},
+        go_file.write("""Resources: []Resource{},
 """)
     else:
-        go_file.write("""Resources: []ParliamentResource{
+        go_file.write("""Resources: []Resource{
 """)
         for resource in resources:
             go_file.write("""{
@@ -120,7 +120,7 @@ def write_resources(resources, go_file):
 
 
 def write_service(service, go_file):
-    go_file.write("""ParliamentService{
+    go_file.write("""Service{
 """)
     write_conditions(service.get("conditions", []), go_file)
     go_file.write("""Prefix: \"{0}\",
@@ -134,46 +134,46 @@ def write_service(service, go_file):
 
 
 def generate_go_file(iam_permissions):
-    with open('../../aws/parliament_iam_permissions.go', 'w') as go_file:
-        go_file.write("""package aws
+    with open('../../internal/parliament/iam_permissions.go', 'w') as go_file:
+        go_file.write("""package parliament
 
-type ParliamentCondition struct {
+type Condition struct {
 Condition   string
 Description string
 Type string
 }
 
-type ParliamentResourceType struct {
+type ResourceType struct {
 ConditionKeys []string
 DependentActions []string
 ResourceType string
 }
 
-type ParliamentPrivilege struct {
+type Privilege struct {
 AccessLevel string
 Description string
 Privilege string
-ResourceTypes []ParliamentResourceType
+ResourceTypes []ResourceType
 }
 
-type ParliamentResource struct {
+type Resource struct {
 Arn           string
 ConditionKeys []string
 Resource      string
 }
 
-type ParliamentService struct {
-Conditions []ParliamentCondition
+type Service struct {
+Conditions []Condition
 Prefix string
-Privileges []ParliamentPrivilege
-Resources []ParliamentResource
+Privileges []Privilege
+Resources []Resource
 ServiceName string
 }
 
-type ParliamentPermissions []ParliamentService
+type Permissions []Service
 
-func getParliamentIamPermissions() ParliamentPermissions {
-permissions := ParliamentPermissions{
+func IamPermissions() Permissions {
+permissions := Permissions{
 """)
         for service in iam_permissions:
             write_service(service, go_file)
@@ -181,4 +181,4 @@ permissions := ParliamentPermissions{
 return permissions
 }
 """)
-    subprocess.run(["gofmt", "-w", "../../aws/parliament_iam_permissions.go"])
+    subprocess.run(["gofmt", "-w", "../../internal/parliament/iam_permissions.go"])